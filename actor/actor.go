@@ -0,0 +1,417 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/channel"
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+var (
+	// ErrStopped is returned when sending to an actor that has stopped.
+	ErrStopped = errors.New("actor is stopped")
+	// ErrMaxRestarts is matched by the error of an actor that stopped because
+	// its handler panicked more often than allowed by WithMaxRestarts.
+	ErrMaxRestarts = errors.New("actor exceeded max restarts")
+)
+
+// ------------------------------------------------------------------------------------------------
+// ~ Types
+// ------------------------------------------------------------------------------------------------
+
+type (
+	// Handler processes a single message. It is only ever called from the
+	// actor's goroutine, so state captured by the handler needs no locking.
+	Handler[Msg any] func(ctx context.Context, msg Msg) error
+
+	// Actor owns the state of a Handler and processes the messages of its
+	// mailbox one at a time. If the handler panics, a fresh handler is created
+	// from the factory, discarding the possibly corrupted state.
+	Actor[Msg any] struct {
+		name    string
+		factory func() Handler[Msg]
+		mailbox *channel.Channel[envelope[Msg]]
+		done    chan struct{}
+		err     error
+
+		// config
+		bufferSize     int
+		maxRestarts    int
+		goOpts         []gofuncy.GoOption
+		errorHandler   gofuncy.ErrorHandler
+		l              *slog.Logger
+		meterProvider  metric.MeterProvider
+		tracerProvider trace.TracerProvider
+
+		// instruments
+		processed gofuncyconv.ActorMessagesProcessed
+		restarts  gofuncyconv.ActorRestarts
+		reg       metric.Registration
+	}
+
+	// Option configures an Actor during construction.
+	Option[Msg any] func(*Actor[Msg])
+
+	envelope[Msg any] struct {
+		ctx  context.Context //nolint:containedctx
+		msg  Msg
+		done chan error
+	}
+)
+
+// ------------------------------------------------------------------------------------------------
+// ~ Options
+// ------------------------------------------------------------------------------------------------
+
+// WithName sets the actor name used for metrics and tracing.
+// Defaults to "gofuncy.actor" when omitted.
+func WithName[Msg any](name string) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.name = name
+	}
+}
+
+// WithMailboxSize sets the mailbox buffer size. Defaults to 0, so Tell blocks
+// until the actor receives the message.
+func WithMailboxSize[Msg any](size int) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.bufferSize = size
+	}
+}
+
+// WithMaxRestarts stops the actor once its handler panicked more than n
+// times. Defaults to -1, restarting indefinitely.
+func WithMaxRestarts[Msg any](n int) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.maxRestarts = n
+	}
+}
+
+// WithGoOptions sets the options used to process each message via gofuncy.Do,
+// e.g. WithTimeout or WithRetry.
+func WithGoOptions[Msg any](opts ...gofuncy.GoOption) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.goOpts = append(a.goOpts, opts...)
+	}
+}
+
+// WithErrorHandler sets the handler for errors of messages sent via Tell.
+// Errors are logged via slog by default.
+func WithErrorHandler[Msg any](h gofuncy.ErrorHandler) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.errorHandler = h
+	}
+}
+
+// WithLogger sets the logger for errors of messages sent via Tell.
+func WithLogger[Msg any](l *slog.Logger) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.l = l
+	}
+}
+
+// WithMeterProvider sets a custom OTel meter provider.
+func WithMeterProvider[Msg any](mp metric.MeterProvider) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.meterProvider = mp
+	}
+}
+
+// WithTracerProvider sets a custom OTel tracer provider.
+func WithTracerProvider[Msg any](tp trace.TracerProvider) Option[Msg] {
+	return func(a *Actor[Msg]) {
+		a.tracerProvider = tp
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ Constructor
+// ------------------------------------------------------------------------------------------------
+
+// New creates and starts an Actor. factory is called to create the handler on
+// start and after every panic. The actor runs until Stop is called or ctx is
+// cancelled. Use WithName to set a custom metric/tracing label; defaults to
+// "gofuncy.actor".
+func New[Msg any](ctx context.Context, factory func() Handler[Msg], opts ...Option[Msg]) *Actor[Msg] {
+	a := &Actor[Msg]{
+		name:        "gofuncy.actor",
+		factory:     factory,
+		done:        make(chan struct{}),
+		maxRestarts: -1,
+		l:           slog.Default(),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(a)
+		}
+	}
+
+	a.mailbox = channel.New[envelope[Msg]](
+		channel.WithName[envelope[Msg]](a.name),
+		channel.WithBuffer[envelope[Msg]](a.bufferSize),
+		channel.WithLogger[envelope[Msg]](a.l),
+		channel.WithMeterProvider[envelope[Msg]](a.meterProvider),
+		channel.WithTracerProvider[envelope[Msg]](a.tracerProvider),
+	)
+
+	a.registerMetrics()
+
+	// the loop must run even if ctx is already cancelled, so it can close done
+	gofuncy.Go(context.WithoutCancel(ctx), func(context.Context) error {
+		return a.run(ctx)
+	},
+		gofuncy.WithName(a.name),
+		gofuncy.WithLogger(a.l),
+		gofuncy.WithMeterProvider(a.meterProvider),
+		gofuncy.WithoutTracing(),
+	)
+
+	return a
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ Public methods
+// ------------------------------------------------------------------------------------------------
+
+// Tell sends msg to the actor without waiting for it to be processed.
+// Returns ErrStopped if the actor has stopped, or the context error if ctx is
+// cancelled while the mailbox is full.
+func (a *Actor[Msg]) Tell(ctx context.Context, msg Msg) error {
+	return a.send(ctx, envelope[Msg]{ctx: ctx, msg: msg})
+}
+
+// Stop stops accepting messages, waits until the queued messages have been
+// processed and returns the error the actor stopped with, if any.
+func (a *Actor[Msg]) Stop() error {
+	a.mailbox.Close()
+	<-a.done
+
+	return a.err
+}
+
+// Done returns a channel that is closed when the actor has stopped.
+func (a *Actor[Msg]) Done() <-chan struct{} {
+	return a.done
+}
+
+// Err returns the reason the actor stopped: nil after Stop, the context error
+// after cancellation, or an error matching ErrMaxRestarts. It returns nil
+// while the actor is running.
+func (a *Actor[Msg]) Err() error {
+	select {
+	case <-a.done:
+		return a.err
+	default:
+		return nil
+	}
+}
+
+// Len returns the number of messages waiting in the mailbox.
+func (a *Actor[Msg]) Len() int {
+	return a.mailbox.Len()
+}
+
+// Name returns the actor name.
+func (a *Actor[Msg]) Name() string {
+	return a.name
+}
+
+// Ask sends the message built by build to the actor and waits until it has
+// been processed. The handler answers by calling the reply function carried
+// by the message; Ask returns the replied value (or the zero value if the
+// handler did not reply) together with the handler's error.
+func Ask[Msg, R any](ctx context.Context, a *Actor[Msg], build func(reply func(R)) Msg) (R, error) {
+	var zero R
+
+	replies := make(chan R, 1)
+	reply := func(v R) {
+		select {
+		case replies <- v:
+		default:
+			// only the first reply is delivered
+		}
+	}
+
+	done := make(chan error, 1)
+
+	if err := a.send(ctx, envelope[Msg]{ctx: ctx, msg: build(reply), done: done}); err != nil {
+		return zero, err
+	}
+
+	select {
+	case err := <-done:
+		return replied(replies, err)
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case <-a.done:
+		select {
+		case err := <-done:
+			return replied(replies, err)
+		default:
+			return zero, ErrStopped
+		}
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ Private methods
+// ------------------------------------------------------------------------------------------------
+
+func (a *Actor[Msg]) send(ctx context.Context, env envelope[Msg]) error {
+	select {
+	case <-a.done:
+		return ErrStopped
+	default:
+	}
+
+	if err := a.mailbox.Send(ctx, env); err != nil {
+		if errors.Is(err, channel.ErrClosed) {
+			return ErrStopped
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (a *Actor[Msg]) run(ctx context.Context) error {
+	defer close(a.done)
+
+	a.err = a.loop(ctx)
+
+	a.mailbox.Close()
+
+	if a.reg != nil {
+		if err := a.reg.Unregister(); err != nil {
+			otel.Handle(err)
+		}
+	}
+
+	if errors.Is(a.err, ErrMaxRestarts) {
+		return a.err
+	}
+
+	return nil
+}
+
+func (a *Actor[Msg]) loop(ctx context.Context) error {
+	handler := a.factory()
+	restarts := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case env, ok := <-a.mailbox.Receive():
+			if !ok {
+				return nil
+			}
+
+			err := a.process(ctx, handler, env)
+
+			var panicErr *gofuncy.PanicError
+			if !errors.As(err, &panicErr) {
+				continue
+			}
+
+			restarts++
+			if a.maxRestarts >= 0 && restarts > a.maxRestarts {
+				return fmt.Errorf("%w: %w", ErrMaxRestarts, err)
+			}
+
+			a.restarts.Add(ctx, 1, a.name)
+
+			handler = a.factory()
+		}
+	}
+}
+
+// process handles a single message via gofuncy.Do. The span of each message
+// is linked to the span of the sender.
+func (a *Actor[Msg]) process(ctx context.Context, handler Handler[Msg], env envelope[Msg]) error {
+	mctx := trace.ContextWithSpanContext(ctx, trace.SpanContextFromContext(env.ctx))
+
+	opts := make([]gofuncy.GoOption, 0, len(a.goOpts)+4)
+	opts = append(opts,
+		gofuncy.WithName(a.name),
+		gofuncy.WithDetachedTrace(),
+		gofuncy.WithMeterProvider(a.meterProvider),
+		gofuncy.WithTracerProvider(a.tracerProvider),
+	)
+	opts = append(opts, a.goOpts...)
+
+	err := gofuncy.Do(mctx, func(ctx context.Context) error {
+		return handler(ctx, env.msg)
+	}, opts...)
+
+	a.processed.Add(ctx, 1, a.name, err != nil)
+
+	switch {
+	case env.done != nil:
+		env.done <- err
+	case err == nil:
+	case a.errorHandler != nil:
+		a.errorHandler(env.ctx, err)
+	default:
+		a.l.ErrorContext(env.ctx, "gofuncy.actor error", "name", a.name, "err", err)
+	}
+
+	return err
+}
+
+// replied returns the value replied by the handler, if any, with err.
+func replied[R any](replies <-chan R, err error) (R, error) {
+	select {
+	case v := <-replies:
+		return v, err
+	default:
+		var zero R
+
+		return zero, err
+	}
+}
+
+func (a *Actor[Msg]) registerMetrics() {
+	mp := a.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	m := mp.Meter(gofuncy.ScopeName, metric.WithSchemaURL(otelsemconv.SchemaURL))
+
+	var err error
+
+	if a.processed, err = gofuncyconv.NewActorMessagesProcessed(m); err != nil {
+		otel.Handle(err)
+	}
+
+	if a.restarts, err = gofuncyconv.NewActorRestarts(m); err != nil {
+		otel.Handle(err)
+	}
+
+	mailboxSize, err := gofuncyconv.NewActorMailboxSize(m)
+	if err != nil {
+		otel.Handle(err)
+		return
+	}
+
+	a.reg, err = m.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		mailboxSize.Observe(o, int64(a.mailbox.Len()), a.name)
+
+		return nil
+	}, mailboxSize.Inst())
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -0,0 +1,187 @@
+package actor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/actor"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+// counterMsg is either an increment (Reply == nil) or a query.
+type counterMsg struct {
+	Delta int
+	Reply func(int)
+	Panic bool
+	Err   error
+}
+
+func newCounter() actor.Handler[counterMsg] {
+	n := 0
+
+	return func(ctx context.Context, msg counterMsg) error {
+		if msg.Panic {
+			panic("boom")
+		}
+
+		n += msg.Delta
+		if msg.Reply != nil {
+			msg.Reply(n)
+		}
+
+		return msg.Err
+	}
+}
+
+func get(reply func(int)) counterMsg { return counterMsg{Reply: reply} }
+
+func ExampleNew() {
+	ctx := context.Background()
+
+	counter := actor.New(ctx, newCounter, actor.WithMailboxSize[counterMsg](8))
+	defer counter.Stop()
+
+	_ = counter.Tell(ctx, counterMsg{Delta: 1})
+	_ = counter.Tell(ctx, counterMsg{Delta: 2})
+
+	n, err := actor.Ask(ctx, counter, get)
+
+	fmt.Println(n, err)
+	// Output:
+	// 3 <nil>
+}
+
+func TestActor_tellAndAsk(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	a := actor.New(t.Context(), newCounter,
+		actor.WithName[counterMsg]("counter"),
+		actor.WithMeterProvider[counterMsg](mp),
+	)
+
+	for range 10 {
+		require.NoError(t, a.Tell(t.Context(), counterMsg{Delta: 1}))
+	}
+
+	n, err := actor.Ask(t.Context(), a, get)
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	require.NoError(t, a.Stop())
+}
+
+func TestActor_askReturnsHandlerError(t *testing.T) {
+	t.Parallel()
+
+	a := actor.New(t.Context(), newCounter)
+	defer a.Stop()
+
+	n, err := actor.Ask(t.Context(), a, func(reply func(int)) counterMsg {
+		return counterMsg{Delta: 5, Reply: reply, Err: errors.New("rejected")}
+	})
+	require.EqualError(t, err, "rejected")
+	assert.Equal(t, 5, n)
+}
+
+func TestActor_tellErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	errs := make(chan error, 1)
+
+	a := actor.New(t.Context(), newCounter,
+		actor.WithErrorHandler[counterMsg](func(ctx context.Context, err error) {
+			errs <- err
+		}),
+	)
+	defer a.Stop()
+
+	require.NoError(t, a.Tell(t.Context(), counterMsg{Err: errors.New("boom")}))
+	require.EqualError(t, <-errs, "boom")
+}
+
+func TestActor_restartsAfterPanic(t *testing.T) {
+	t.Parallel()
+
+	a := actor.New(t.Context(), newCounter)
+	defer a.Stop()
+
+	require.NoError(t, a.Tell(t.Context(), counterMsg{Delta: 7}))
+
+	_, err := actor.Ask(t.Context(), a, func(reply func(int)) counterMsg {
+		return counterMsg{Panic: true}
+	})
+
+	var panicErr *gofuncy.PanicError
+	require.ErrorAs(t, err, &panicErr)
+
+	// the handler was recreated with fresh state
+	n, err := actor.Ask(t.Context(), a, get)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestActor_maxRestarts(t *testing.T) {
+	t.Parallel()
+
+	a := actor.New(t.Context(), newCounter,
+		actor.WithMaxRestarts[counterMsg](1),
+		actor.WithErrorHandler[counterMsg](func(ctx context.Context, err error) {}),
+	)
+
+	require.NoError(t, a.Tell(t.Context(), counterMsg{Panic: true}))
+	require.NoError(t, a.Tell(t.Context(), counterMsg{Panic: true}))
+
+	<-a.Done()
+
+	require.ErrorIs(t, a.Err(), actor.ErrMaxRestarts)
+	require.ErrorIs(t, a.Tell(t.Context(), counterMsg{Delta: 1}), actor.ErrStopped)
+	require.ErrorIs(t, a.Stop(), actor.ErrMaxRestarts)
+}
+
+func TestActor_stopDrainsMailbox(t *testing.T) {
+	t.Parallel()
+
+	total := make(chan int, 1)
+
+	a := actor.New(t.Context(), newCounter, actor.WithMailboxSize[counterMsg](10))
+
+	for range 5 {
+		require.NoError(t, a.Tell(t.Context(), counterMsg{Delta: 1}))
+	}
+
+	require.NoError(t, a.Tell(t.Context(), counterMsg{Reply: func(n int) { total <- n }}))
+	require.NoError(t, a.Stop())
+
+	assert.Equal(t, 5, <-total)
+	require.ErrorIs(t, a.Tell(t.Context(), counterMsg{Delta: 1}), actor.ErrStopped)
+
+	_, err := actor.Ask(t.Context(), a, get)
+	require.ErrorIs(t, err, actor.ErrStopped)
+}
+
+func TestActor_contextCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	a := actor.New(ctx, newCounter)
+
+	cancel()
+	<-a.Done()
+
+	require.ErrorIs(t, a.Err(), context.Canceled)
+	require.ErrorIs(t, a.Tell(t.Context(), counterMsg{Delta: 1}), actor.ErrStopped)
+}
@@ -0,0 +1,4 @@
+// Package actor provides a goroutine owning its state and processing messages
+// from a channel.Channel mailbox one at a time, with panic recovery, restarts
+// and OpenTelemetry metrics.
+package actor
@@ -0,0 +1,286 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/foomo/gofuncy/semconv"
+)
+
+// ------------------------------------------------------------------------------------------------
+// ~ Algorithms
+// ------------------------------------------------------------------------------------------------
+
+// LimitSample is a single observation reported to a LimitAlgorithm.
+type LimitSample struct {
+	// Latency is the execution time of the observed call.
+	Latency time.Duration
+	// InFlight is the number of executions admitted when the call completed.
+	InFlight int
+	// Dropped reports whether the call failed in a way that signals overload.
+	Dropped bool
+}
+
+// LimitAlgorithm computes a new concurrency limit from the current limit and
+// an observed sample. Update is called with the limiter's lock held, so
+// implementations may keep state without additional synchronization, but an
+// instance must not be shared between limiters.
+type LimitAlgorithm interface {
+	Update(limit float64, sample LimitSample) float64
+}
+
+type aimdAlgorithm struct {
+	backoffRatio     float64
+	latencyThreshold time.Duration
+}
+
+// LimitAIMD returns an additive-increase/multiplicative-decrease algorithm.
+// The limit grows by 1 when a call succeeds while at least half of the limit
+// is in use, and is multiplied by backoffRatio (e.g. 0.9) when a call is
+// dropped or slower than latencyThreshold (0 disables the latency check).
+func LimitAIMD(backoffRatio float64, latencyThreshold time.Duration) LimitAlgorithm {
+	return &aimdAlgorithm{backoffRatio: backoffRatio, latencyThreshold: latencyThreshold}
+}
+
+func (a *aimdAlgorithm) Update(limit float64, s LimitSample) float64 {
+	if s.Dropped || (a.latencyThreshold > 0 && s.Latency > a.latencyThreshold) {
+		return limit * a.backoffRatio
+	}
+
+	if float64(s.InFlight)*2 >= limit {
+		return limit + 1
+	}
+
+	return limit
+}
+
+type vegasAlgorithm struct {
+	minRTT time.Duration
+}
+
+// LimitVegas returns a TCP Vegas-style algorithm. It tracks the lowest
+// observed latency as the no-load baseline and estimates the queue size as
+// limit * (1 - minRTT/rtt). The limit grows while the estimated queue is
+// small and shrinks once it exceeds a threshold proportional to log10(limit).
+func LimitVegas() LimitAlgorithm {
+	return &vegasAlgorithm{}
+}
+
+func (v *vegasAlgorithm) Update(limit float64, s LimitSample) float64 {
+	step := max(1, math.Log10(limit))
+
+	if s.Dropped {
+		return limit - step
+	}
+
+	if s.Latency <= 0 {
+		return limit
+	}
+
+	if v.minRTT == 0 || s.Latency < v.minRTT {
+		v.minRTT = s.Latency
+	}
+
+	queue := limit * (1 - float64(v.minRTT)/float64(s.Latency))
+
+	switch {
+	case queue > 6*step:
+		return limit - step
+	case queue < 3*step && float64(s.InFlight)*2 >= limit:
+		return limit + step
+	default:
+		return limit
+	}
+}
+
+type gradientAlgorithm struct {
+	tolerance float64
+	longRTT   float64
+}
+
+// LimitGradient returns a gradient-based algorithm that compares the current
+// latency against a slowly moving average. The limit is scaled by
+// tolerance*longRTT/rtt (clamped to [0.5, 1]) plus a queue allowance of
+// sqrt(limit), and smoothed to avoid oscillation. A tolerance of 1.5 allows
+// latency to grow by 50% before the limit shrinks.
+func LimitGradient(tolerance float64) LimitAlgorithm {
+	return &gradientAlgorithm{tolerance: tolerance}
+}
+
+func (g *gradientAlgorithm) Update(limit float64, s LimitSample) float64 {
+	const smoothing = 0.2
+
+	rtt := float64(s.Latency)
+
+	gradient := 0.5
+	if !s.Dropped && rtt > 0 {
+		if g.longRTT == 0 {
+			g.longRTT = rtt
+		} else {
+			g.longRTT = g.longRTT*0.95 + rtt*0.05
+		}
+
+		gradient = max(0.5, min(1, g.tolerance*g.longRTT/rtt))
+	}
+
+	next := limit*gradient + math.Sqrt(limit)
+	if next > limit && float64(s.InFlight)*2 < limit {
+		// app-limited: do not grow the limit without demand
+		return limit
+	}
+
+	return limit*(1-smoothing) + next*smoothing
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ AdaptiveLimiter
+// ------------------------------------------------------------------------------------------------
+
+// AdaptiveLimiterOption configures an AdaptiveLimiter.
+type AdaptiveLimiterOption func(*adaptiveLimiterConfig)
+
+type adaptiveLimiterConfig struct {
+	name          string
+	initial       int
+	minLimit      int
+	maxLimit      int
+	algorithm     LimitAlgorithm
+	meterProvider metric.MeterProvider
+}
+
+// AdaptiveLimiter is a Limiter whose limit is adjusted from observed latency
+// and errors. Pass it to WithLimiter on Do, Go, NewGroup, Map, etc.; every
+// invocation then reports its outcome. It is safe for concurrent use and
+// should be shared across all calls to the same dependency.
+type AdaptiveLimiter struct {
+	fifoLimiter
+
+	// estimate is the limit computed by the algorithm; executions are
+	// admitted up to its integer part, but at least one
+	estimate float64
+	cfg      adaptiveLimiterConfig
+	reg      metric.Registration
+}
+
+// NewAdaptiveLimiter creates a new AdaptiveLimiter with the given options and
+// registers its limit and in-flight gauges.
+func NewAdaptiveLimiter(opts ...AdaptiveLimiterOption) *AdaptiveLimiter {
+	l := &AdaptiveLimiter{
+		cfg: adaptiveLimiterConfig{
+			name:     "gofuncy.limiter",
+			initial:  20,
+			minLimit: 1,
+			maxLimit: 1000,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&l.cfg)
+	}
+
+	if l.cfg.algorithm == nil {
+		l.cfg.algorithm = LimitAIMD(0.9, 0)
+	}
+
+	l.setEstimate(float64(max(l.cfg.minLimit, min(l.cfg.initial, l.cfg.maxLimit))))
+	l.reg = l.registerGauges(l.cfg.meterProvider, l.cfg.name)
+
+	return l
+}
+
+// Observe reports the outcome of a call and adjusts the limit. Context
+// cancellation and panics are not treated as overload signals.
+func (l *AdaptiveLimiter) Observe(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := l.cfg.algorithm.Update(l.estimate, LimitSample{
+		Latency:  latency,
+		InFlight: int(l.inFlight),
+		Dropped:  isOverloadError(err),
+	})
+
+	l.setEstimate(max(float64(l.cfg.minLimit), min(next, float64(l.cfg.maxLimit))))
+	l.notify()
+}
+
+// setEstimate sets the limit computed by the algorithm.
+// Must be called while l.mu is held or before l is shared.
+func (l *AdaptiveLimiter) setEstimate(v float64) {
+	l.estimate = v
+	l.limit = max(int64(v), 1)
+}
+
+// Limit returns the current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.estimate)
+}
+
+func (l *AdaptiveLimiter) waitAttributes(context.Context) []attribute.KeyValue {
+	return []attribute.KeyValue{semconv.LimiterName(l.cfg.name)}
+}
+
+// Close unregisters the limiter's gauges.
+func (l *AdaptiveLimiter) Close() error {
+	if l.reg == nil {
+		return nil
+	}
+
+	return l.reg.Unregister()
+}
+
+// AdaptiveLimiterName sets the name reported with the limiter's gauges.
+// Defaults to "gofuncy.limiter".
+func AdaptiveLimiterName(name string) AdaptiveLimiterOption {
+	return func(c *adaptiveLimiterConfig) {
+		c.name = name
+	}
+}
+
+// AdaptiveLimiterInitial sets the initial limit. Defaults to 20.
+func AdaptiveLimiterInitial(n int) AdaptiveLimiterOption {
+	return func(c *adaptiveLimiterConfig) {
+		c.initial = n
+	}
+}
+
+// AdaptiveLimiterBounds sets the minimum and maximum limit. Defaults to 1 and 1000.
+func AdaptiveLimiterBounds(minLimit, maxLimit int) AdaptiveLimiterOption {
+	return func(c *adaptiveLimiterConfig) {
+		c.minLimit = minLimit
+		c.maxLimit = maxLimit
+	}
+}
+
+// AdaptiveLimiterAlgorithm sets the algorithm used to adjust the limit.
+// Defaults to LimitAIMD(0.9, 0).
+func AdaptiveLimiterAlgorithm(a LimitAlgorithm) AdaptiveLimiterOption {
+	return func(c *adaptiveLimiterConfig) {
+		c.algorithm = a
+	}
+}
+
+// AdaptiveLimiterMeterProvider sets a custom meter provider for the gauges.
+func AdaptiveLimiterMeterProvider(mp metric.MeterProvider) AdaptiveLimiterOption {
+	return func(c *adaptiveLimiterConfig) {
+		c.meterProvider = mp
+	}
+}
+
+func isOverloadError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var panicErr *PanicError
+
+	return !errors.As(err, &panicErr)
+}
@@ -0,0 +1,165 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveLimiter_boundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	l := gofuncy.NewAdaptiveLimiter(
+		gofuncy.AdaptiveLimiterInitial(2),
+		gofuncy.AdaptiveLimiterBounds(2, 2),
+		gofuncy.AdaptiveLimiterMeterProvider(mp),
+	)
+	defer l.Close()
+
+	var active, peak atomic.Int32
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimiter(l))
+
+	for range 10 {
+		g.Add(func(ctx context.Context) error {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			active.Add(-1)
+
+			return nil
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Zero(t, l.InFlight())
+}
+
+func TestAdaptiveLimiter_aimdShrinksOnErrors(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewAdaptiveLimiter(
+		gofuncy.AdaptiveLimiterInitial(100),
+		gofuncy.AdaptiveLimiterAlgorithm(gofuncy.LimitAIMD(0.5, 0)),
+	)
+	defer l.Close()
+
+	for range 3 {
+		_ = gofuncy.Do(t.Context(), func(ctx context.Context) error {
+			return errors.New("overloaded")
+		}, gofuncy.WithLimiter(l))
+	}
+
+	assert.Equal(t, 12, l.Limit())
+}
+
+func TestAdaptiveLimiter_aimdGrowsUnderLoad(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewAdaptiveLimiter(
+		gofuncy.AdaptiveLimiterInitial(2),
+		gofuncy.AdaptiveLimiterBounds(1, 10),
+	)
+	defer l.Close()
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Go(func() {
+			_ = gofuncy.Do(t.Context(), func(ctx context.Context) error {
+				time.Sleep(time.Millisecond)
+				return nil
+			}, gofuncy.WithLimiter(l))
+		})
+	}
+
+	wg.Wait()
+	assert.Greater(t, l.Limit(), 2)
+	assert.LessOrEqual(t, l.Limit(), 10)
+}
+
+func TestAdaptiveLimiter_ignoresCancellation(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewAdaptiveLimiter(gofuncy.AdaptiveLimiterInitial(10))
+	defer l.Close()
+
+	l.Observe(time.Millisecond, context.Canceled)
+	l.Observe(time.Millisecond, &gofuncy.PanicError{Value: "boom"})
+
+	assert.Equal(t, 10, l.Limit())
+}
+
+func TestAdaptiveLimiter_acquireRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewAdaptiveLimiter(gofuncy.AdaptiveLimiterInitial(1), gofuncy.AdaptiveLimiterBounds(1, 1))
+	defer l.Close()
+
+	require.NoError(t, l.Acquire(t.Context(), 1))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, l.Acquire(ctx, 1), context.DeadlineExceeded)
+
+	l.Release(1)
+	require.NoError(t, l.Acquire(t.Context(), 1))
+	l.Release(1)
+	assert.Zero(t, l.InFlight())
+}
+
+func TestLimitVegas(t *testing.T) {
+	t.Parallel()
+
+	alg := gofuncy.LimitVegas()
+
+	// establish a 10ms baseline, then grow while latency stays at baseline
+	limit := alg.Update(10, gofuncy.LimitSample{Latency: 10 * time.Millisecond, InFlight: 10})
+	assert.Greater(t, limit, 10.0)
+
+	// latency doubles: estimated queue is half the limit, so shrink
+	shrunk := alg.Update(20, gofuncy.LimitSample{Latency: 20 * time.Millisecond, InFlight: 20})
+	assert.Less(t, shrunk, 20.0)
+
+	assert.Less(t, alg.Update(20, gofuncy.LimitSample{Dropped: true}), 20.0)
+}
+
+func TestLimitGradient(t *testing.T) {
+	t.Parallel()
+
+	alg := gofuncy.LimitGradient(1.5)
+
+	// steady latency under load grows the limit
+	limit := 10.0
+	for range 10 {
+		limit = alg.Update(limit, gofuncy.LimitSample{Latency: 10 * time.Millisecond, InFlight: int(limit)})
+	}
+
+	assert.Greater(t, limit, 10.0)
+
+	// a latency spike shrinks it
+	spiked := alg.Update(limit, gofuncy.LimitSample{Latency: time.Second, InFlight: int(limit)})
+	assert.Less(t, spiked, limit)
+
+	// no demand: never grows
+	assert.Equal(t, 40.0, alg.Update(40, gofuncy.LimitSample{Latency: 10 * time.Millisecond, InFlight: 1}))
+}
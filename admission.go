@@ -0,0 +1,136 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrInsufficientDeadline is matched by errors returned when deadline-aware
+// admission rejects an invocation.
+var ErrInsufficientDeadline = errors.New("insufficient deadline")
+
+const (
+	// latencyWindow is the number of recent durations kept per operation.
+	latencyWindow = 256
+	// admissionMinSamples is the number of durations required before
+	// deadline-aware admission starts rejecting work.
+	admissionMinSamples = 10
+)
+
+// latencyEstimates holds the recent durations of successful invocations per
+// operation scope (see operationScope), shared by all invocations using
+// WithDeadlineAdmission.
+var latencyEstimates sync.Map // map[string]*latencyEstimate
+
+// DeadlineAdmissionError is returned when the remaining context deadline is
+// shorter than the estimated duration of the invocation.
+type DeadlineAdmissionError struct {
+	Remaining  time.Duration
+	Estimate   time.Duration
+	Percentile float64
+}
+
+// Error implements the error interface for DeadlineAdmissionError.
+func (e *DeadlineAdmissionError) Error() string {
+	return fmt.Sprintf("%s: %s remaining, p%g duration is %s", ErrInsufficientDeadline, e.Remaining, e.Percentile*100, e.Estimate)
+}
+
+// Unwrap returns ErrInsufficientDeadline.
+func (e *DeadlineAdmissionError) Unwrap() error {
+	return ErrInsufficientDeadline
+}
+
+// latencyEstimate keeps a sliding window of durations and answers percentile
+// queries from a sorted copy that is rebuilt at most once per new sample.
+type latencyEstimate struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	sorted  []time.Duration
+	dirty   bool
+}
+
+func (e *latencyEstimate) record(d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.samples) < latencyWindow {
+		e.samples = append(e.samples, d)
+	} else {
+		e.samples[e.next] = d
+		e.next = (e.next + 1) % latencyWindow
+	}
+
+	e.dirty = true
+}
+
+// percentile returns the duration at percentile p (0..1) and false while fewer
+// than admissionMinSamples durations were recorded.
+func (e *latencyEstimate) percentile(p float64) (time.Duration, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.samples) < admissionMinSamples {
+		return 0, false
+	}
+
+	if e.dirty {
+		e.sorted = append(e.sorted[:0], e.samples...)
+		slices.Sort(e.sorted)
+		e.dirty = false
+	}
+
+	idx := int(math.Ceil(p*float64(len(e.sorted)))) - 1
+
+	return e.sorted[max(0, min(idx, len(e.sorted)-1))], true
+}
+
+// latencyEstimateFor returns the latency estimate shared by all invocations of
+// the operation scope.
+func latencyEstimateFor(scope string) *latencyEstimate {
+	if v, ok := latencyEstimates.Load(scope); ok {
+		return v.(*latencyEstimate) //nolint:forcetypeassert
+	}
+
+	v, _ := latencyEstimates.LoadOrStore(scope, &latencyEstimate{})
+
+	return v.(*latencyEstimate) //nolint:forcetypeassert
+}
+
+// checkDeadline returns a *DeadlineAdmissionError if the remaining context
+// deadline is shorter than the given percentile of recent durations of the
+// operation scope.
+func checkDeadline(ctx context.Context, percentile float64, scope string) error {
+	remaining, ok := RemainingBudgetFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if estimate, ok := latencyEstimateFor(scope).percentile(percentile); ok && remaining < estimate {
+		return &DeadlineAdmissionError{Remaining: remaining, Estimate: estimate, Percentile: percentile}
+	}
+
+	return nil
+}
+
+// withLatencyRecording records the duration of every successful invocation
+// for deadline-aware admission of the operation scope.
+func withLatencyRecording(fn Func, scope string) Func {
+	est := latencyEstimateFor(scope)
+
+	return func(ctx context.Context) error {
+		start := time.Now()
+
+		err := fn(ctx)
+		if err == nil {
+			est.record(time.Since(start))
+		}
+
+		return err
+	}
+}
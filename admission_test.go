@@ -0,0 +1,152 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestWithDeadlineAdmission(t *testing.T) {
+	t.Parallel()
+
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("admission-reject"),
+		gofuncy.WithDeadlineAdmission(0.5),
+	}
+
+	slow := func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+
+	for range 10 {
+		require.NoError(t, gofuncy.Do(t.Context(), slow, opts...))
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Millisecond)
+	defer cancel()
+
+	called := false
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		called = true
+		return nil
+	}, opts...)
+	require.ErrorIs(t, err, gofuncy.ErrInsufficientDeadline)
+	assert.False(t, called)
+
+	var admissionErr *gofuncy.DeadlineAdmissionError
+	require.ErrorAs(t, err, &admissionErr)
+	assert.GreaterOrEqual(t, admissionErr.Estimate, 10*time.Millisecond)
+	assert.Less(t, admissionErr.Remaining, 2*time.Millisecond)
+
+	ctx, cancel = context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	require.NoError(t, gofuncy.Do(ctx, slow, opts...))
+}
+
+func TestWithDeadlineAdmission_warmup(t *testing.T) {
+	t.Parallel()
+
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("admission-warmup"),
+		gofuncy.WithDeadlineAdmission(0.5),
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Nanosecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		return ctx.Err()
+	}, opts...)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, gofuncy.ErrInsufficientDeadline)
+}
+
+func TestWithDeadlineAdmission_ignoresFailures(t *testing.T) {
+	t.Parallel()
+
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("admission-failures"),
+		gofuncy.WithDeadlineAdmission(0.5),
+	}
+
+	for range 10 {
+		_ = gofuncy.Do(t.Context(), func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return errors.New("boom")
+		}, opts...)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, gofuncy.Do(ctx, func(ctx context.Context) error {
+		return nil
+	}, opts...))
+}
+
+func TestWithDeadlineAdmission_beforeLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	sem := semaphore.NewWeighted(1)
+
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("admission-limiter"),
+		gofuncy.WithDeadlineAdmission(0.5),
+		gofuncy.WithLimiter(sem),
+	}
+
+	slow := func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+
+	for range 10 {
+		require.NoError(t, gofuncy.Do(t.Context(), slow, opts...))
+	}
+
+	// the limiter is saturated: the rejection must not wait for it
+	require.True(t, sem.TryAcquire(1))
+	defer sem.Release(1)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Millisecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, slow, opts...)
+	require.ErrorIs(t, err, gofuncy.ErrInsufficientDeadline)
+	require.NotErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithDeadlineAdmission_unnamedCallSites(t *testing.T) {
+	t.Parallel()
+
+	// a single call site, warmed up with slow invocations
+	do := func(ctx context.Context, fn gofuncy.Func) error {
+		return gofuncy.Do(ctx, fn, gofuncy.WithDeadlineAdmission(0.5))
+	}
+
+	for range 10 {
+		require.NoError(t, do(t.Context(), func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}))
+	}
+
+	fast := func(ctx context.Context) error { return nil }
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, do(ctx, fast), gofuncy.ErrInsufficientDeadline)
+
+	// another unnamed call site does not share the durations
+	require.NoError(t, gofuncy.Do(ctx, fast, gofuncy.WithDeadlineAdmission(0.5)))
+}
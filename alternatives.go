@@ -0,0 +1,152 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/foomo/gofuncy/semconv"
+)
+
+// ErrQuorumNotReached is matched by the error of Any and Quorum when too many
+// alternatives failed for the outcome to be reached.
+var ErrQuorumNotReached = errors.New("quorum not reached")
+
+// Any runs all alternatives concurrently in a Group and returns the value of
+// the first one to succeed, cancelling the others. If all alternatives fail,
+// it returns an error matching ErrQuorumNotReached that joins their errors.
+// The index of the winning alternative is recorded as the gofuncy.alternative
+// attribute on the group span and on the span of the winner.
+// All GroupOption options apply except WithFailFast.
+// Use WithName to set a custom metric/tracing label; defaults to "gofuncy.any".
+func Any[R any](ctx context.Context, fns []func(ctx context.Context) (R, error), opts ...GroupOption) (R, error) {
+	values, err := runAlternatives(ctx, 1, fns, "gofuncy.any", opts)
+	if err != nil {
+		var zero R
+
+		return zero, err
+	}
+
+	return values[0], nil
+}
+
+// Quorum runs all alternatives concurrently in a Group and returns the values
+// of the first k to succeed, in completion order, cancelling the others as
+// soon as k succeeded. Once more than len(fns)-k alternatives failed, it
+// cancels the others and returns an error matching ErrQuorumNotReached that
+// joins the collected errors. The indices of the winning alternatives are
+// recorded as the gofuncy.alternatives attribute on the group span.
+// All GroupOption options apply except WithFailFast.
+// Use WithName to set a custom metric/tracing label; defaults to "gofuncy.quorum".
+func Quorum[R any](ctx context.Context, k int, fns []func(ctx context.Context) (R, error), opts ...GroupOption) ([]R, error) {
+	return runAlternatives(ctx, k, fns, "gofuncy.quorum", opts)
+}
+
+// alternatives tracks the outcome of Any and Quorum.
+type alternatives[R any] struct {
+	mu      sync.Mutex
+	k       int
+	n       int
+	values  []R
+	winners []int
+	errs    []error
+	failed  int
+	decided bool
+}
+
+func runAlternatives[R any](ctx context.Context, k int, fns []func(ctx context.Context) (R, error), name string, opts []GroupOption) ([]R, error) {
+	if k < 1 || k > len(fns) {
+		return nil, fmt.Errorf("%w: need %d of %d alternatives", ErrQuorumNotReached, k, len(fns))
+	}
+
+	groupOpts := make([]GroupOption, 0, len(opts)+2)
+	groupOpts = append(groupOpts, WithName(name))
+	groupOpts = append(groupOpts, opts...)
+	// a failing alternative must not cancel the others
+	groupOpts = append(groupOpts, groupOnlyOpt(func(o *options) { o.failFast = false }))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	g := NewGroup(ctx, groupOpts...)
+	if g.span != nil && k > 1 {
+		g.span.SetAttributes(semconv.Quorum(k))
+	}
+
+	a := &alternatives[R]{
+		k:    k,
+		n:    len(fns),
+		errs: make([]error, len(fns)),
+	}
+
+	for i, fn := range fns {
+		g.Add(func(ctx context.Context) error {
+			v, err := fn(ctx)
+
+			a.mu.Lock()
+			defer a.mu.Unlock()
+
+			// alternatives completing after the outcome was decided have been
+			// cancelled and do not count as failures
+			if a.decided {
+				return nil
+			}
+
+			if err != nil {
+				a.errs[i] = err
+				a.failed++
+
+				if a.failed > a.n-a.k {
+					a.decided = true
+
+					cancel()
+				}
+
+				return err
+			}
+
+			a.values = append(a.values, v)
+			a.winners = append(a.winners, i)
+
+			if a.k == 1 {
+				trace.SpanFromContext(ctx).SetAttributes(semconv.Alternative(i))
+			}
+
+			if len(a.values) == a.k {
+				a.decided = true
+
+				if g.span != nil {
+					if a.k == 1 {
+						g.span.SetAttributes(semconv.Alternative(i))
+					} else {
+						g.span.SetAttributes(semconv.Alternatives(a.winners))
+					}
+				}
+
+				cancel()
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait() //nolint:contextcheck
+
+	if len(a.values) >= k {
+		return a.values, nil
+	}
+
+	if err := errors.Join(a.errs...); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrQuorumNotReached, err)
+	}
+
+	// alternatives that never ran because ctx was cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrQuorumNotReached, err)
+	}
+
+	return nil, ErrQuorumNotReached
+}
@@ -0,0 +1,171 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/semconv"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleAny() {
+	ctx := context.Background()
+
+	v, err := gofuncy.Any(ctx, []func(ctx context.Context) (string, error){
+		func(ctx context.Context) (string, error) { return "", errors.New("primary down") },
+		func(ctx context.Context) (string, error) { return "replica", nil },
+	})
+
+	fmt.Println(v, err)
+	// Output:
+	// replica <nil>
+}
+
+// replica returns an alternative that yields v after d, or fails when cancelled.
+func replica[T any](d time.Duration, v T, err error) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		select {
+		case <-time.After(d):
+			return v, err
+		case <-ctx.Done():
+			var zero T
+
+			return zero, ctx.Err()
+		}
+	}
+}
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	v, err := gofuncy.Any(t.Context(), []func(ctx context.Context) (string, error){
+		replica(time.Minute, "slow", nil),
+		replica(time.Millisecond, "", errors.New("boom")),
+		replica(5*time.Millisecond, "fast", nil),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fast", v)
+	assert.Less(t, time.Since(start), time.Second, "losing alternatives must be cancelled")
+}
+
+func TestAny_allFail(t *testing.T) {
+	t.Parallel()
+
+	errA, errB := errors.New("a"), errors.New("b")
+
+	_, err := gofuncy.Any(t.Context(), []func(ctx context.Context) (int, error){
+		replica(time.Millisecond, 0, errA),
+		replica(2*time.Millisecond, 0, errB),
+	})
+	require.ErrorIs(t, err, gofuncy.ErrQuorumNotReached)
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errB)
+
+	_, err = gofuncy.Any[int](t.Context(), nil)
+	require.ErrorIs(t, err, gofuncy.ErrQuorumNotReached)
+}
+
+func TestAny_ignoresFailFast(t *testing.T) {
+	t.Parallel()
+
+	v, err := gofuncy.Any(t.Context(), []func(ctx context.Context) (int, error){
+		replica(time.Millisecond, 0, errors.New("boom")),
+		replica(10*time.Millisecond, 2, nil),
+	}, gofuncy.WithFailFast())
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestQuorum(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	values, err := gofuncy.Quorum(t.Context(), 2, []func(ctx context.Context) (int, error){
+		replica(time.Millisecond, 0, errors.New("boom")),
+		replica(5*time.Millisecond, 2, nil),
+		replica(time.Minute, 3, nil),
+		replica(50*time.Millisecond, 4, nil),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, values)
+	assert.Less(t, time.Since(start), time.Second, "outstanding alternatives must be cancelled")
+}
+
+func TestQuorum_unreachable(t *testing.T) {
+	t.Parallel()
+
+	errA, errB := errors.New("a"), errors.New("b")
+	start := time.Now()
+
+	_, err := gofuncy.Quorum(t.Context(), 2, []func(ctx context.Context) (int, error){
+		replica(time.Millisecond, 0, errA),
+		replica(time.Minute, 2, nil),
+		replica(2*time.Millisecond, 0, errB),
+	})
+	require.ErrorIs(t, err, gofuncy.ErrQuorumNotReached)
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errB)
+	assert.Less(t, time.Since(start), time.Second, "outstanding alternatives must be cancelled")
+
+	_, err = gofuncy.Quorum(t.Context(), 3, []func(ctx context.Context) (int, error){
+		replica(time.Millisecond, 1, nil),
+	})
+	require.ErrorIs(t, err, gofuncy.ErrQuorumNotReached)
+}
+
+func TestAny_tracing(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	_, err := gofuncy.Any(t.Context(), []func(ctx context.Context) (int, error){
+		replica(time.Minute, 0, nil),
+		replica(time.Millisecond, 1, nil),
+	}, gofuncy.WithName("lookup"), gofuncy.WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	tp.ForceFlush(t.Context())
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.group lookup")
+
+	v, ok := findAttr(span.Attributes, semconv.AlternativeKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), v.AsInt64())
+}
+
+func TestQuorum_tracing(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	_, err := gofuncy.Quorum(t.Context(), 2, []func(ctx context.Context) (int, error){
+		replica(50*time.Millisecond, 0, nil),
+		replica(time.Minute, 1, nil),
+		replica(time.Millisecond, 2, nil),
+	}, gofuncy.WithName("write"), gofuncy.WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	tp.ForceFlush(t.Context())
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.group write")
+
+	v, ok := findAttr(span.Attributes, semconv.AlternativesKey)
+	require.True(t, ok)
+	assert.Equal(t, []int64{2, 0}, v.AsInt64Slice())
+
+	v, ok = findAttr(span.Attributes, semconv.QuorumKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(2), v.AsInt64())
+}
@@ -0,0 +1,128 @@
+package gofuncy
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff returns the delay before the nth retry attempt (0-indexed).
+// Implementations must be safe for concurrent use.
+type Backoff func(attempt int) time.Duration
+
+// BackoffConstant returns a Backoff that always waits the same duration.
+func BackoffConstant(d time.Duration) Backoff {
+	return func(_ int) time.Duration {
+		return d
+	}
+}
+
+// BackoffExponential returns a Backoff with exponential growth, jitter, and a cap.
+// The delay for attempt n is: min(initial * multiplier^n, max) +/- 25% jitter.
+func BackoffExponential(initial time.Duration, multiplier float64, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := float64(initial) * math.Pow(multiplier, float64(attempt))
+		if delay > float64(maxDelay) {
+			delay = float64(maxDelay)
+		}
+
+		jitter := delay * 0.25
+		delay = delay - jitter + rand.Float64()*2*jitter //nolint:gosec
+
+		return time.Duration(delay)
+	}
+}
+
+// BackoffLinear returns a Backoff that grows by step with every attempt.
+// The delay for attempt n is: min(initial + step*n, max).
+func BackoffLinear(initial, step, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return capDelay(float64(initial)+float64(step)*float64(attempt), maxDelay)
+	}
+}
+
+// BackoffFibonacci returns a Backoff that follows the Fibonacci sequence.
+// The delay for attempt n is: min(base * fib(n+1), max), i.e. base, base,
+// 2*base, 3*base, 5*base, ...
+func BackoffFibonacci(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		a, b := 1.0, 1.0
+		for range attempt {
+			a, b = b, a+b
+			if a*float64(base) >= float64(maxDelay) {
+				return maxDelay
+			}
+		}
+
+		return capDelay(a*float64(base), maxDelay)
+	}
+}
+
+// BackoffFullJitter returns an exponential Backoff with full jitter.
+// The delay for attempt n is random in [0, min(base * 2^n, max)).
+func BackoffFullJitter(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		ceil := float64(capDelay(float64(base)*math.Pow(2, float64(attempt)), maxDelay))
+
+		return time.Duration(rand.Float64() * ceil) //nolint:gosec
+	}
+}
+
+// BackoffEqualJitter returns an exponential Backoff with equal jitter.
+// The delay for attempt n is t/2 + random in [0, t/2), where
+// t = min(base * 2^n, max).
+func BackoffEqualJitter(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		half := float64(capDelay(float64(base)*math.Pow(2, float64(attempt)), maxDelay)) / 2
+
+		return time.Duration(half + rand.Float64()*half) //nolint:gosec
+	}
+}
+
+// BackoffDecorrelatedJitter returns a Backoff with decorrelated jitter, where
+// each delay is random in [base, previous*3), capped at max. The sequence is
+// derived from the attempt number alone, so the Backoff holds no state and may
+// be shared across concurrent retries.
+func BackoffDecorrelatedJitter(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := float64(base)
+		for range attempt + 1 {
+			upper := delay * 3
+			delay = float64(base) + rand.Float64()*(upper-float64(base)) //nolint:gosec
+
+			if delay >= float64(maxDelay) {
+				return maxDelay
+			}
+		}
+
+		return time.Duration(delay)
+	}
+}
+
+// WithMaxDelay returns a Backoff that caps every delay of b at maxDelay.
+func (b Backoff) WithMaxDelay(maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return min(b(attempt), maxDelay)
+	}
+}
+
+// WithJitter returns a Backoff that randomizes every delay of b by +/- factor
+// (e.g. 0.25 for +/- 25%). The factor is clamped to [0, 1].
+func (b Backoff) WithJitter(factor float64) Backoff {
+	factor = max(0, min(factor, 1))
+
+	return func(attempt int) time.Duration {
+		delay := float64(b(attempt))
+		jitter := delay * factor
+
+		return time.Duration(delay - jitter + rand.Float64()*2*jitter) //nolint:gosec
+	}
+}
+
+func capDelay(delay float64, maxDelay time.Duration) time.Duration {
+	if delay > float64(maxDelay) {
+		return maxDelay
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,103 @@
+package gofuncy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffLinear(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffLinear(100*time.Millisecond, 50*time.Millisecond, 250*time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, b(0))
+	assert.Equal(t, 150*time.Millisecond, b(1))
+	assert.Equal(t, 200*time.Millisecond, b(2))
+	assert.Equal(t, 250*time.Millisecond, b(3))
+	assert.Equal(t, 250*time.Millisecond, b(100))
+}
+
+func TestBackoffFibonacci(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffFibonacci(10*time.Millisecond, time.Second)
+
+	expected := []time.Duration{10, 10, 20, 30, 50, 80, 130}
+	for i, want := range expected {
+		assert.Equal(t, want*time.Millisecond, b(i), "attempt %d", i)
+	}
+
+	assert.Equal(t, time.Second, b(1000))
+}
+
+func TestBackoffFullJitter(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffFullJitter(100*time.Millisecond, time.Second)
+
+	for range 20 {
+		assert.Less(t, b(0), 100*time.Millisecond)
+		assert.Less(t, b(2), 400*time.Millisecond)
+		assert.Less(t, b(50), time.Second)
+		assert.GreaterOrEqual(t, b(3), time.Duration(0))
+	}
+}
+
+func TestBackoffEqualJitter(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffEqualJitter(100*time.Millisecond, time.Second)
+
+	for range 20 {
+		d1 := b(1)
+		assert.GreaterOrEqual(t, d1, 100*time.Millisecond)
+		assert.Less(t, d1, 200*time.Millisecond)
+
+		d50 := b(50)
+		assert.GreaterOrEqual(t, d50, 500*time.Millisecond)
+		assert.LessOrEqual(t, d50, time.Second)
+	}
+}
+
+func TestBackoffDecorrelatedJitter(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffDecorrelatedJitter(100*time.Millisecond, time.Second)
+
+	for range 20 {
+		d0 := b(0)
+		assert.GreaterOrEqual(t, d0, 100*time.Millisecond)
+		assert.Less(t, d0, 300*time.Millisecond)
+
+		for attempt := range 10 {
+			d := b(attempt)
+			assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+			assert.LessOrEqual(t, d, time.Second)
+		}
+	}
+}
+
+func TestBackoff_WithMaxDelay(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffLinear(0, time.Second, time.Hour).WithMaxDelay(2500 * time.Millisecond)
+	assert.Equal(t, time.Second, b(1))
+	assert.Equal(t, 2*time.Second, b(2))
+	assert.Equal(t, 2500*time.Millisecond, b(3))
+}
+
+func TestBackoff_WithJitter(t *testing.T) {
+	t.Parallel()
+
+	b := gofuncy.BackoffConstant(100 * time.Millisecond).WithJitter(0.5)
+
+	for range 20 {
+		d := b(0)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+	}
+
+	assert.Equal(t, 100*time.Millisecond, gofuncy.BackoffConstant(100*time.Millisecond).WithJitter(0)(3))
+}
@@ -0,0 +1,283 @@
+package gofuncy
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// CacheOption configures result cache behavior.
+type CacheOption func(*cacheConfig)
+
+type cacheConfig struct {
+	ttl          time.Duration
+	maxEntries   int
+	refreshAhead time.Duration
+	staleIfError bool
+	maxStale     time.Duration
+}
+
+// Cache holds the results of successful calls keyed by a caller-provided key.
+// It is safe for concurrent use and should be shared across all calls to the
+// same operation. Use it with DoValue to cache values; with functions that only
+// return an error, a cache hit skips the call.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	cfg     cacheConfig
+}
+
+type cacheEntry struct {
+	key        string
+	value      any
+	storedAt   time.Time
+	refreshing bool
+}
+
+// NewCache creates a new Cache with the given options.
+func NewCache(opts ...CacheOption) *Cache {
+	c := &Cache{
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		cfg: cacheConfig{
+			ttl:        time.Minute,
+			maxEntries: 1000,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&c.cfg)
+	}
+
+	return c
+}
+
+// Len returns the number of entries currently held, including stale ones.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Invalidate removes the entry for key, if any.
+func (c *Cache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// middleware returns a Middleware that serves fresh results from the cache,
+// stores successful results, refreshes entries ahead of expiry in the
+// background, and optionally serves stale results when the call fails.
+func (c *Cache) middleware(m metric.Meter, name string, keyFn func(ctx context.Context) string, slot *resultSlot, refreshOpts []GoOption) Middleware {
+	hits, err := gofuncyconv.NewCacheHits(m)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	misses, err := gofuncyconv.NewCacheMisses(m)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	stale, err := gofuncyconv.NewCacheStale(m)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next Func) Func {
+		return func(ctx context.Context) error {
+			own := slot.target(ctx)
+			if own == nil {
+				own = &resultSlot{}
+			}
+
+			key := keyFn(ctx)
+
+			if value, ok, refresh := c.get(key); ok {
+				own.value = value
+
+				hits.Add(ctx, 1, name)
+
+				if refresh {
+					Go(context.WithoutCancel(ctx), c.refresh(next, key, slot), refreshOpts...)
+				}
+
+				return nil
+			}
+
+			misses.Add(ctx, 1, name)
+
+			err := next(ctx)
+			if err == nil {
+				c.set(key, own.value)
+
+				return nil
+			}
+
+			if value, ok := c.getStale(key); ok {
+				own.value = value
+
+				stale.Add(ctx, 1, name)
+
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// refresh returns a Func that re-runs next for key and stores the result. The
+// value is redirected into a fresh slot so the caller's slot is not touched.
+func (c *Cache) refresh(next Func, key string, slot *resultSlot) Func {
+	return func(ctx context.Context) error {
+		defer c.endRefresh(key)
+
+		fresh := &resultSlot{}
+		if slot != nil {
+			ctx = redirectResult(ctx, slot, fresh)
+		}
+
+		if err := next(ctx); err != nil {
+			return err
+		}
+
+		c.set(key, fresh.value)
+
+		return nil
+	}
+}
+
+// get returns the fresh value for key. refresh reports whether the caller
+// should start a background refresh; only one refresh per key is started.
+func (c *Cache) get(key string) (any, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false, false
+	}
+
+	e := el.Value.(*cacheEntry) //nolint:forcetypeassert
+
+	age := time.Since(e.storedAt)
+	if age >= c.cfg.ttl {
+		if !c.cfg.staleIfError || (c.cfg.maxStale > 0 && age >= c.cfg.ttl+c.cfg.maxStale) {
+			c.lru.Remove(el)
+			delete(c.entries, key)
+		}
+
+		return nil, false, false
+	}
+
+	c.lru.MoveToFront(el)
+
+	refresh := c.cfg.refreshAhead > 0 && !e.refreshing && age >= c.cfg.ttl-c.cfg.refreshAhead
+	if refresh {
+		e.refreshing = true
+	}
+
+	return e.value, true, refresh
+}
+
+// getStale returns the last stored value for key regardless of its TTL, if
+// stale-if-error is enabled and the entry is within the maximum staleness.
+func (c *Cache) getStale(key string) (any, bool) {
+	if !c.cfg.staleIfError {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*cacheEntry) //nolint:forcetypeassert
+	if c.cfg.maxStale > 0 && time.Since(e.storedAt) >= c.cfg.ttl+c.cfg.maxStale {
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *Cache) set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry) //nolint:forcetypeassert
+		e.value = value
+		e.storedAt = time.Now()
+
+		c.lru.MoveToFront(el)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, storedAt: time.Now()})
+
+	if c.cfg.maxEntries > 0 && c.lru.Len() > c.cfg.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key) //nolint:forcetypeassert
+	}
+}
+
+func (c *Cache) endRefresh(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*cacheEntry).refreshing = false //nolint:forcetypeassert
+	}
+}
+
+// CacheTTL sets how long a stored result is served as fresh. Defaults to 1m.
+func CacheTTL(d time.Duration) CacheOption {
+	return func(c *cacheConfig) {
+		c.ttl = d
+	}
+}
+
+// CacheMaxEntries sets the maximum number of entries. The least recently used
+// entry is evicted when the limit is exceeded. Defaults to 1000; 0 means no limit.
+func CacheMaxEntries(n int) CacheOption {
+	return func(c *cacheConfig) {
+		c.maxEntries = n
+	}
+}
+
+// CacheRefreshAhead enables background refreshes via Go for entries that are
+// served within d of their expiry, so hot keys never expire under load.
+func CacheRefreshAhead(d time.Duration) CacheOption {
+	return func(c *cacheConfig) {
+		c.refreshAhead = d
+	}
+}
+
+// CacheStaleIfError serves the last successful result when the wrapped call
+// fails, as long as the result expired less than maxStale ago. A maxStale of
+// 0 serves stale results until the entry is evicted.
+func CacheStaleIfError(maxStale time.Duration) CacheOption {
+	return func(c *cacheConfig) {
+		c.staleIfError = true
+		c.maxStale = maxStale
+	}
+}
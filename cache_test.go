@@ -0,0 +1,220 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticKey(key string) func(ctx context.Context) string {
+	return func(ctx context.Context) string { return key }
+}
+
+func TestCache_servesFreshResult(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+	cache := gofuncy.NewCache(gofuncy.CacheTTL(time.Minute))
+
+	var calls atomic.Int32
+
+	for range 3 {
+		v, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (int, error) {
+			return int(calls.Add(1)), nil
+		}, gofuncy.WithCache(cache, staticKey("k")), gofuncy.WithMeterProvider(mp))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, v)
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCache_expiresAfterTTL(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache(gofuncy.CacheTTL(10 * time.Millisecond))
+
+	var calls atomic.Int32
+
+	fn := func(ctx context.Context) (int, error) {
+		return int(calls.Add(1)), nil
+	}
+
+	v, err := gofuncy.DoValue(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	time.Sleep(20 * time.Millisecond)
+
+	v, err = gofuncy.DoValue(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestCache_doesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache()
+
+	var calls atomic.Int32
+
+	for range 2 {
+		_, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			return 0, errors.New("fail")
+		}, gofuncy.WithCache(cache, staticKey("k")))
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Zero(t, cache.Len())
+}
+
+func TestCache_lruEviction(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache(gofuncy.CacheMaxEntries(2))
+
+	var calls atomic.Int32
+
+	get := func(key string) {
+		_, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+			calls.Add(1)
+			return key, nil
+		}, gofuncy.WithCache(cache, staticKey(key)))
+		require.NoError(t, err)
+	}
+
+	get("a")
+	get("b")
+	get("a") // hit, a becomes most recently used
+	get("c") // evicts b
+
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, int32(3), calls.Load())
+
+	get("a") // still cached
+	assert.Equal(t, int32(3), calls.Load())
+
+	get("b") // evicted, recomputed
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestCache_staleIfError(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache(
+		gofuncy.CacheTTL(5*time.Millisecond),
+		gofuncy.CacheStaleIfError(time.Minute),
+	)
+
+	v, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+		return "good", nil
+	}, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+	assert.Equal(t, "good", v)
+
+	time.Sleep(10 * time.Millisecond)
+
+	v, err = gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+		return "", errors.New("backend down")
+	}, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+	assert.Equal(t, "good", v)
+}
+
+func TestCache_staleIfErrorMaxStale(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache(
+		gofuncy.CacheTTL(5*time.Millisecond),
+		gofuncy.CacheStaleIfError(5*time.Millisecond),
+	)
+
+	_, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+		return "good", nil
+	}, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, err = gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+		return "", errors.New("backend down")
+	}, gofuncy.WithCache(cache, staticKey("k")))
+	require.EqualError(t, err, "backend down")
+}
+
+func TestCache_refreshAhead(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache(
+		gofuncy.CacheTTL(50*time.Millisecond),
+		gofuncy.CacheRefreshAhead(40*time.Millisecond),
+	)
+
+	var calls atomic.Int32
+
+	refreshed := make(chan struct{})
+
+	fn := func(ctx context.Context) (int, error) {
+		n := calls.Add(1)
+		if n == 2 {
+			close(refreshed)
+		}
+
+		return int(n), nil
+	}
+
+	v, err := gofuncy.DoValue(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	time.Sleep(15 * time.Millisecond)
+
+	// within the refresh-ahead window: served from cache, refreshed in background
+	v, err = gofuncy.DoValue(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for refresh")
+	}
+
+	require.Eventually(t, func() bool {
+		v, err := gofuncy.DoValue(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k")))
+		return err == nil && v == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestCache_invalidate(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache()
+
+	var calls atomic.Int32
+
+	fn := func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}
+
+	require.NoError(t, gofuncy.Do(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k"))))
+	require.NoError(t, gofuncy.Do(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k"))))
+	assert.Equal(t, int32(1), calls.Load())
+
+	cache.Invalidate("k")
+
+	require.NoError(t, gofuncy.Do(t.Context(), fn, gofuncy.WithCache(cache, staticKey("k"))))
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,54 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/foomo/gofuncy/semconv"
+)
+
+// ErrStopped is the cancellation cause of a routine stopped via its StopFunc.
+// Use context.Cause to tell it apart from a cancelled parent context.
+var ErrStopped = errors.New("routine stopped")
+
+// stopFunc returns a StopFunc cancelling with ErrStopped as the cause.
+func stopFunc(cancel context.CancelCauseFunc) StopFunc {
+	return func() {
+		cancel(ErrStopped)
+	}
+}
+
+// withCause annotates a cancellation error with the cause of ctx, so callers
+// see why ctx was cancelled instead of only context.Canceled. errors.Is
+// matches both context.Canceled and the cause.
+func withCause(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	cause := context.Cause(ctx)
+	if cause == nil || cause == context.Canceled || errors.Is(err, cause) { //nolint:errorlint
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", err, cause)
+}
+
+// withCancelCause annotates cancellation errors with the cause of the routine
+// context and records the cause on the routine span.
+func withCancelCause(fn Func) Func {
+	return func(ctx context.Context) error {
+		err := fn(ctx)
+
+		if caused := withCause(ctx, err); caused != err { //nolint:errorlint
+			trace.SpanFromContext(ctx).SetAttributes(semconv.CancelCause(context.Cause(ctx).Error()))
+
+			return caused
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,142 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/semconv"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestGroup_failFastCause(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	causes := make(chan error, 1)
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithFailFast())
+
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+
+		return ctx.Err()
+	}, gofuncy.WithName("sibling"))
+	g.Add(func(ctx context.Context) error {
+		return boom
+	}, gofuncy.WithName("failing"))
+
+	cause := <-causes
+
+	var itemErr *gofuncy.ItemError
+	require.ErrorAs(t, cause, &itemErr)
+	assert.Equal(t, "failing", itemErr.Name)
+	assert.Equal(t, 1, itemErr.Index)
+	require.ErrorIs(t, cause, boom)
+
+	err := g.Wait()
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "context canceled: failing[1]: boom")
+}
+
+func TestGroup_failFastCauseTracing(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithName("sync"),
+		gofuncy.WithFailFast(),
+		gofuncy.WithTracerProvider(tp),
+	)
+
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, gofuncy.WithName("sibling"))
+	g.Add(func(ctx context.Context) error {
+		return errors.New("boom")
+	}, gofuncy.WithName("failing"))
+
+	require.Error(t, g.Wait())
+
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+
+	v, ok := findAttr(findSpan(t, spans, "gofuncy.group sync").Attributes, semconv.CancelCauseKey)
+	require.True(t, ok)
+	assert.Equal(t, "failing[1]: boom", v.AsString())
+
+	v, ok = findAttr(findSpan(t, spans, "gofuncy.group.add sibling").Attributes, semconv.CancelCauseKey)
+	require.True(t, ok)
+	assert.Equal(t, "failing[1]: boom", v.AsString())
+}
+
+func TestWaitWithStop_cause(t *testing.T) {
+	t.Parallel()
+
+	wait := gofuncy.WaitWithStop(t.Context(), func(ctx context.Context, stop gofuncy.StopFunc) error {
+		stop()
+		assert.ErrorIs(t, context.Cause(ctx), gofuncy.ErrStopped)
+
+		return ctx.Err()
+	})
+
+	err := wait()
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, gofuncy.ErrStopped)
+}
+
+func TestGoWithCancel_cause(t *testing.T) {
+	t.Parallel()
+
+	errs := make(chan error, 1)
+	running := make(chan struct{})
+
+	stop := gofuncy.GoWithCancel(t.Context(), func(ctx context.Context) error {
+		close(running)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, gofuncy.WithErrorHandler(func(ctx context.Context, err error) {
+		errs <- err
+	}))
+
+	<-running
+	stop()
+
+	require.ErrorIs(t, <-errs, gofuncy.ErrStopped)
+}
+
+func TestGoWithCancel_parentCause(t *testing.T) {
+	t.Parallel()
+
+	errShutdown := errors.New("shutdown")
+	errs := make(chan error, 1)
+	running := make(chan struct{})
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+
+	gofuncy.GoWithCancel(ctx, func(ctx context.Context) error {
+		close(running)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, gofuncy.WithErrorHandler(func(ctx context.Context, err error) {
+		errs <- err
+	}))
+
+	<-running
+	cancel(errShutdown)
+
+	err := <-errs
+	require.ErrorIs(t, err, errShutdown)
+	require.NotErrorIs(t, err, gofuncy.ErrStopped)
+}
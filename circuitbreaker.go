@@ -9,7 +9,9 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/foomo/gofuncy/semconv"
 	"github.com/foomo/gofuncy/semconv/gofuncyconv"
 )
 
@@ -81,12 +83,26 @@ func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
 }
 
 // middleware returns a Middleware that implements the circuit breaker pattern.
-func (cb *CircuitBreaker) middleware(m metric.Meter, name string) Middleware {
+// When tracing is enabled, rejections and state transitions are recorded as
+// events on the current span.
+func (cb *CircuitBreaker) middleware(m metric.Meter, name string, tracing bool) Middleware {
 	rejected, err := gofuncyconv.NewGoroutinesRejected(m)
 	if err != nil {
 		otel.Handle(err)
 	}
 
+	reject := func(ctx context.Context, state CircuitState) error {
+		rejected.Add(ctx, 1, name)
+
+		if tracing {
+			trace.SpanFromContext(ctx).AddEvent(semconv.EventCircuitRejected, trace.WithAttributes(
+				semconv.CircuitState(state.String()),
+			))
+		}
+
+		return ErrCircuitOpen
+	}
+
 	return func(fn Func) Func {
 		return func(ctx context.Context) error {
 			cb.mu.Lock()
@@ -96,25 +112,19 @@ func (cb *CircuitBreaker) middleware(m metric.Meter, name string) Middleware {
 				if time.Since(cb.lastFailedAt) < cb.cfg.cooldown {
 					cb.mu.Unlock()
 
-					rejected.Add(ctx, 1, name)
-
-					return ErrCircuitOpen
+					return reject(ctx, CircuitOpen)
 				}
 				// Cooldown elapsed — transition to half-open for a probe
-				notify, from := cb.transition(CircuitHalfOpen)
+				from := cb.transition(CircuitHalfOpen)
 				cb.mu.Unlock()
 
-				if notify != nil {
-					notify(from, CircuitHalfOpen)
-				}
+				cb.notify(ctx, tracing, from, CircuitHalfOpen)
 
 			case CircuitHalfOpen:
 				// Another probe is already in flight; reject
 				cb.mu.Unlock()
 
-				rejected.Add(ctx, 1, name)
-
-				return ErrCircuitOpen
+				return reject(ctx, CircuitHalfOpen)
 
 			default: // CircuitClosed
 				cb.mu.Unlock()
@@ -124,26 +134,19 @@ func (cb *CircuitBreaker) middleware(m metric.Meter, name string) Middleware {
 
 			cb.mu.Lock()
 
-			var (
-				notify       func(CircuitState, CircuitState)
-				from         CircuitState
-				transitionTo CircuitState
-			)
-
 			if err != nil && cb.cfg.failureIf(err) {
 				cb.failures++
 				cb.lastFailedAt = time.Now()
 
+				from := cb.state
 				if cb.failures >= cb.cfg.threshold {
-					notify, from = cb.transition(CircuitOpen)
-					transitionTo = CircuitOpen
+					from = cb.transition(CircuitOpen)
 				}
 
+				to := cb.state
 				cb.mu.Unlock()
 
-				if notify != nil {
-					notify(from, transitionTo)
-				}
+				cb.notify(ctx, tracing, from, to)
 
 				return err
 			}
@@ -151,33 +154,47 @@ func (cb *CircuitBreaker) middleware(m metric.Meter, name string) Middleware {
 			// Success — reset
 			cb.failures = 0
 
+			from := cb.state
 			if cb.state == CircuitHalfOpen {
-				notify, from = cb.transition(CircuitClosed)
-				transitionTo = CircuitClosed
+				from = cb.transition(CircuitClosed)
 			}
 
+			to := cb.state
 			cb.mu.Unlock()
 
-			if notify != nil {
-				notify(from, transitionTo)
-			}
+			cb.notify(ctx, tracing, from, to)
 
 			return err
 		}
 	}
 }
 
-// transition sets the new state and returns the callback to invoke after
-// the mutex is released (if any). Must be called while cb.mu is held.
-func (cb *CircuitBreaker) transition(to CircuitState) (func(CircuitState, CircuitState), CircuitState) {
+// transition sets the new state and returns the previous one.
+// Must be called while cb.mu is held.
+func (cb *CircuitBreaker) transition(to CircuitState) CircuitState {
 	prev := cb.state
 	cb.state = to
 
-	if cb.cfg.onStateChange != nil && prev != to {
-		return cb.cfg.onStateChange, prev
+	return prev
+}
+
+// notify reports a state transition to the span and the configured callback.
+// Must be called after cb.mu is released. It is a no-op if from == to.
+func (cb *CircuitBreaker) notify(ctx context.Context, tracing bool, from, to CircuitState) {
+	if from == to {
+		return
+	}
+
+	if tracing {
+		trace.SpanFromContext(ctx).AddEvent(semconv.EventCircuitStateChange, trace.WithAttributes(
+			semconv.CircuitStateFrom(from.String()),
+			semconv.CircuitState(to.String()),
+		))
 	}
 
-	return nil, prev
+	if cb.cfg.onStateChange != nil {
+		cb.cfg.onStateChange(from, to)
+	}
 }
 
 // CircuitBreakerThreshold sets the number of consecutive failures before the
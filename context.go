@@ -2,12 +2,13 @@ package gofuncy
 
 import (
 	"context"
+	"time"
 )
 
 type contextKey int
 
 // Context wraps a standard context with helper methods for accessing
-// gofuncy routine name and parent information.
+// gofuncy routine name, parent, and resilience information.
 type Context struct {
 	context.Context //nolint:containedctx
 }
@@ -23,12 +24,23 @@ const (
 	contextKeyName contextKey = iota
 	contextKeyParent
 	contextKeyRoutine
+	contextKeyAttempt
+	contextKeyResult
+	contextKeyTenant
+	contextKeyPriority
 )
 
-// routineInfo stores both name and parent in a single context value to reduce allocations.
+// routineInfo stores the name, parent and start time in a single context value to reduce allocations.
 type routineInfo struct {
-	name   string
-	parent string
+	name      string
+	parent    string
+	startedAt time.Time
+}
+
+// attemptInfo stores the current retry attempt and the configured maximum.
+type attemptInfo struct {
+	attempt     int
+	maxAttempts int
 }
 
 // Ctx wraps a context.Context with gofuncy helper methods.
@@ -46,9 +58,29 @@ func (c Context) Parent() string {
 	return ParentFromContext(c)
 }
 
-// Root returns the context with the `root` name set
+// Attempt returns the 1-indexed retry attempt from the given context
+func (c Context) Attempt() int {
+	return AttemptFromContext(c)
+}
+
+// MaxAttempts returns the maximum number of retry attempts from the given context
+func (c Context) MaxAttempts() int {
+	return MaxAttemptsFromContext(c)
+}
+
+// StartedAt returns the time the routine started from the given context
+func (c Context) StartedAt() time.Time {
+	return StartedAtFromContext(c)
+}
+
+// RemainingBudget returns the time left until the context deadline
+func (c Context) RemainingBudget() (time.Duration, bool) {
+	return RemainingBudgetFromContext(c)
+}
+
+// Root returns the context with the `root` name set and no parent
 func (c Context) Root() context.Context {
-	return injectNameIntoContext(c.Context, NameRoot)
+	return injectRoutineInfoIntoContext(c.Context, routineInfo{name: NameRoot})
 }
 
 // NameFromContext extracts the routine name from the given context, falling back to a default "noname" if not found.
@@ -65,10 +97,6 @@ func NameFromContext(ctx context.Context) string {
 	return NameNoName
 }
 
-func injectParentIntoContext(ctx context.Context, name string) context.Context {
-	return context.WithValue(ctx, contextKeyParent, name)
-}
-
 // ParentFromContext extracts the parent routine name from the given context.
 func ParentFromContext(ctx context.Context) string {
 	// check combined key first
@@ -83,10 +111,88 @@ func ParentFromContext(ctx context.Context) string {
 	return ""
 }
 
-func injectRoutineIntoContext(ctx context.Context, name, parent string) context.Context {
-	return context.WithValue(ctx, contextKeyRoutine, routineInfo{name: name, parent: parent})
+func injectRoutineInfoIntoContext(ctx context.Context, ri routineInfo) context.Context {
+	return context.WithValue(ctx, contextKeyRoutine, ri)
 }
 
 func injectNameIntoContext(ctx context.Context, name string) context.Context {
 	return context.WithValue(ctx, contextKeyName, name)
 }
+
+// AttemptFromContext extracts the 1-indexed retry attempt from the given
+// context. Returns 1 when the routine is not running under Retry.
+func AttemptFromContext(ctx context.Context) int {
+	if ai, ok := ctx.Value(contextKeyAttempt).(attemptInfo); ok {
+		return ai.attempt
+	}
+
+	return 1
+}
+
+// MaxAttemptsFromContext extracts the maximum number of retry attempts from
+// the given context. Returns 1 when the routine is not running under Retry.
+func MaxAttemptsFromContext(ctx context.Context) int {
+	if ai, ok := ctx.Value(contextKeyAttempt).(attemptInfo); ok {
+		return ai.maxAttempts
+	}
+
+	return 1
+}
+
+// StartedAtFromContext extracts the time the current routine started from the
+// given context. Returns the zero time if not found.
+func StartedAtFromContext(ctx context.Context) time.Time {
+	if ri, ok := ctx.Value(contextKeyRoutine).(routineInfo); ok {
+		return ri.startedAt
+	}
+
+	return time.Time{}
+}
+
+// RemainingBudgetFromContext returns the time left until the context deadline,
+// as set by WithTimeout or the caller. The second return value is false if the
+// context has no deadline. The duration is never negative.
+func RemainingBudgetFromContext(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+
+	return max(time.Until(deadline), 0), true
+}
+
+// ContextWithTenant returns a copy of ctx carrying the tenant key used by
+// FairLimiter to queue work fairly across tenants.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, contextKeyTenant, tenant)
+}
+
+// TenantFromContext extracts the tenant key from the given context.
+// Returns an empty string if not found.
+func TenantFromContext(ctx context.Context) string {
+	if value, ok := ctx.Value(contextKeyTenant).(string); ok {
+		return value
+	}
+
+	return ""
+}
+
+// ContextWithPriority returns a copy of ctx carrying the priority class used
+// by FairLimiter. Higher values are admitted first.
+func ContextWithPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, contextKeyPriority, priority)
+}
+
+// PriorityFromContext extracts the priority class from the given context.
+// Returns 0 if not found.
+func PriorityFromContext(ctx context.Context) int {
+	if value, ok := ctx.Value(contextKeyPriority).(int); ok {
+		return value
+	}
+
+	return 0
+}
+
+func injectAttemptIntoContext(ctx context.Context, attempt, maxAttempts int) context.Context {
+	return context.WithValue(ctx, contextKeyAttempt, attemptInfo{attempt: attempt, maxAttempts: maxAttempts})
+}
@@ -38,6 +38,26 @@ func TestCtx_Root(t *testing.T) {
 	assert.Equal(t, gofuncy.NameRoot, gofuncy.Ctx(ctx).Name())
 }
 
+func TestCtx_RootInsideRoutine(t *testing.T) {
+	t.Parallel()
+
+	var name, parent, childParent string
+
+	require.NoError(t, gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		root := gofuncy.Ctx(ctx).Root()
+		name, parent = gofuncy.NameFromContext(root), gofuncy.ParentFromContext(root)
+
+		return gofuncy.Do(root, func(ctx context.Context) error {
+			childParent = gofuncy.Ctx(ctx).Parent()
+			return nil
+		}, gofuncy.WithName("child"))
+	}, gofuncy.WithName("x")))
+
+	assert.Equal(t, gofuncy.NameRoot, name)
+	assert.Empty(t, parent)
+	assert.Equal(t, gofuncy.NameRoot, childParent)
+}
+
 func TestCtx_Parent(t *testing.T) {
 	t.Parallel()
 	assert.Empty(t, gofuncy.Ctx(t.Context()).Parent())
@@ -112,3 +132,78 @@ func TestContext_existingDeadlineShorterThanTimeout(t *testing.T) {
 		t.Fatal("timed out — parent deadline should have triggered within 20ms")
 	}
 }
+
+func TestCtx_AttemptDefaults(t *testing.T) {
+	t.Parallel()
+
+	c := gofuncy.Ctx(t.Context())
+	assert.Equal(t, 1, c.Attempt())
+	assert.Equal(t, 1, c.MaxAttempts())
+	assert.True(t, c.StartedAt().IsZero())
+
+	_, ok := c.RemainingBudget()
+	assert.False(t, ok)
+}
+
+func TestCtx_AttemptUnderRetry(t *testing.T) {
+	t.Parallel()
+
+	var attempts, maxAttempts []int
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		c := gofuncy.Ctx(ctx)
+		attempts = append(attempts, c.Attempt())
+		maxAttempts = append(maxAttempts, c.MaxAttempts())
+
+		if c.Attempt() < 3 {
+			return fmt.Errorf("transient")
+		}
+
+		return nil
+	}, gofuncy.WithRetry(4, gofuncy.RetryBackoff(gofuncy.BackoffConstant(0))))
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.Equal(t, []int{4, 4, 4}, maxAttempts)
+}
+
+func TestCtx_StartedAtStableAcrossAttempts(t *testing.T) {
+	t.Parallel()
+
+	before := time.Now()
+
+	var startedAt []time.Time
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		startedAt = append(startedAt, gofuncy.Ctx(ctx).StartedAt())
+		if len(startedAt) < 2 {
+			return fmt.Errorf("transient")
+		}
+
+		return nil
+	}, gofuncy.WithRetry(2, gofuncy.RetryBackoff(gofuncy.BackoffConstant(time.Millisecond))))
+
+	require.NoError(t, err)
+	require.Len(t, startedAt, 2)
+	assert.False(t, startedAt[0].Before(before))
+	assert.Equal(t, startedAt[0], startedAt[1])
+}
+
+func TestCtx_RemainingBudget(t *testing.T) {
+	t.Parallel()
+
+	var (
+		remaining time.Duration
+		ok        bool
+	)
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		remaining, ok = gofuncy.Ctx(ctx).RemainingBudget()
+		return nil
+	}, gofuncy.WithTimeout(time.Second))
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Greater(t, remaining, 900*time.Millisecond)
+	assert.LessOrEqual(t, remaining, time.Second)
+}
@@ -2,8 +2,15 @@ package gofuncy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 )
 
+// ErrResultType is returned by DoValue and Async when a middleware such as
+// WithCache supplied a value of a different type than the call returns.
+var ErrResultType = errors.New("result type mismatch")
+
 // Do executes fn synchronously with the full middleware chain (resilience,
 // telemetry, tracing) and returns the error directly. Unlike Go, it does not
 // spawn a goroutine.
@@ -17,13 +24,100 @@ func Do(ctx context.Context, fn Func, opts ...GoOption) error {
 	run := withContextInjection(fn, o.name)
 	run = buildChain(run, &o, "gofuncy.do", o.callerSkip+3)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			return err
-		}
+	if err := admit(ctx, &o); err != nil {
+		return err
+	}
 
-		defer o.limiter.Release(1)
+	if o.limiter != nil {
+		defer o.limiter.Release(o.limiterWeight())
 	}
 
 	return run(ctx)
 }
+
+// DoValue is like Do but returns the value produced by fn. With
+// WithSingleflight, coalesced callers receive the value of the shared
+// execution. Use WithName to set a custom metric/tracing label; defaults to
+// "gofuncy.do".
+func DoValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...GoOption) (T, error) {
+	o := newGoOptions(opts)
+	if o.name == "" {
+		o.name = "gofuncy.do"
+	}
+
+	slot := newResultSlot[T]()
+	o.result = slot
+
+	inner := Func(func(ctx context.Context) error {
+		v, err := fn(ctx)
+		slot.target(ctx).value = v
+
+		return err
+	})
+
+	run := withContextInjection(inner, o.name)
+	run = buildChain(run, &o, "gofuncy.do", o.callerSkip+3)
+
+	if err := admit(ctx, &o); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	if o.limiter != nil {
+		defer o.limiter.Release(o.limiterWeight())
+	}
+
+	if err := run(ctx); err != nil {
+		v, _ := slot.value.(T)
+		return v, err
+	}
+
+	return resultValue[T](slot)
+}
+
+// resultSlot carries the value produced by a value-returning call (DoValue)
+// through the Func-based middleware chain, so that middlewares such as
+// singleflight and cache can share and substitute values.
+type resultSlot struct {
+	value any
+	// typ names the result type; it scopes singleflight keys so that only
+	// calls returning the same type share a result.
+	typ string
+}
+
+func newResultSlot[T any]() *resultSlot {
+	return &resultSlot{typ: reflect.TypeFor[T]().String()}
+}
+
+// resultValue returns the value of slot as T. It fails with ErrResultType if
+// the value is of another type.
+func resultValue[T any](slot *resultSlot) (T, error) {
+	v, ok := slot.value.(T)
+	if !ok && slot.value != nil {
+		return v, fmt.Errorf("%w: got %T, want %s", ErrResultType, slot.value, slot.typ)
+	}
+
+	return v, nil
+}
+
+// resultRedirect points the writes of the owner slot to another slot, so a
+// middleware can re-run the chain (e.g. a background refresh) without
+// touching the caller's slot.
+type resultRedirect struct {
+	owner *resultSlot
+	to    *resultSlot
+}
+
+// target returns the slot the value should be written to for this
+// invocation. It is s unless a middleware redirected s via the context.
+func (s *resultSlot) target(ctx context.Context) *resultSlot {
+	if r, ok := ctx.Value(contextKeyResult).(resultRedirect); ok && r.owner == s {
+		return r.to
+	}
+
+	return s
+}
+
+func redirectResult(ctx context.Context, owner, to *resultSlot) context.Context {
+	return context.WithValue(ctx, contextKeyResult, resultRedirect{owner: owner, to: to})
+}
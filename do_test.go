@@ -10,6 +10,7 @@ import (
 	"github.com/foomo/gofuncy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
 )
 
 func ExampleDo() {
@@ -125,6 +126,23 @@ func TestDo_withTimeout(t *testing.T) {
 	require.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestDo_withWeight(t *testing.T) {
+	t.Parallel()
+
+	sem := semaphore.NewWeighted(5)
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		assert.True(t, sem.TryAcquire(2))
+		assert.False(t, sem.TryAcquire(1))
+		sem.Release(2)
+
+		return nil
+	}, gofuncy.WithLimiter(sem), gofuncy.WithWeight(3))
+
+	require.NoError(t, err)
+	assert.True(t, sem.TryAcquire(5))
+}
+
 func TestDo_withCircuitBreaker(t *testing.T) {
 	t.Parallel()
 
@@ -180,3 +198,32 @@ func TestDo_fullResilienceChain(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int32(3), calls.Load())
 }
+
+func TestDoValue(t *testing.T) {
+	t.Parallel()
+
+	v, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+}
+
+func TestDoValue_retry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	v, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+		if calls.Add(1) < 2 {
+			return "", fmt.Errorf("transient")
+		}
+
+		return "ok", nil
+	}, gofuncy.WithRetry(3, gofuncy.RetryBackoff(gofuncy.BackoffConstant(0))))
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok", v)
+	assert.Equal(t, int32(2), calls.Load())
+}
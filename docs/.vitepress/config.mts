@@ -40,6 +40,10 @@ export default defineConfig({
 					{ text: 'Group', link: '/api/group' },
 					{ text: 'All', link: '/api/all' },
 					{ text: 'Map', link: '/api/map' },
+					{ text: 'KeyedExecutor', link: '/api/keyedexecutor' },
+					{ text: 'Future', link: '/api/future' },
+					{ text: 'Any & Quorum', link: '/api/alternatives' },
+					{ text: 'Gather', link: '/api/gather' },
 					{ text: 'Options', link: '/api/options' },
 				],
 			},
@@ -47,6 +51,7 @@ export default defineConfig({
 				text: 'Packages',
 				items: [
 					{ text: 'Channel', link: '/api/channel' },
+					{ text: 'Actor', link: '/api/actor' },
 				],
 			},
 			{
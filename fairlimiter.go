@@ -0,0 +1,366 @@
+package gofuncy
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/foomo/gofuncy/semconv"
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// FairLimiterOption configures a FairLimiter.
+type FairLimiterOption func(*fairLimiterConfig)
+
+type fairLimiterConfig struct {
+	name          string
+	tenantFn      func(ctx context.Context) string
+	priorityFn    func(ctx context.Context) int
+	weightFn      func(tenant string) float64
+	meterProvider metric.MeterProvider
+}
+
+// FairLimiter is a Limiter for multi-tenant workloads. Callers that cannot be
+// admitted immediately are queued by priority class first; within a class,
+// tenants share the capacity by weighted fair queuing, so a tenant submitting
+// a burst of work cannot starve the others. Priority classes are strict: lower
+// classes are only admitted while no higher class is waiting.
+//
+// The tenant and priority are extracted from the context passed to Acquire,
+// by default via TenantFromContext and PriorityFromContext. Pass the limiter
+// to WithLimiter on Do, Go, NewGroup, Map, etc. It is safe for concurrent use.
+type FairLimiter struct {
+	mu       sync.Mutex
+	capacity int64
+	inFlight int64
+	vtime    float64
+	seq      uint64
+	queue    fairQueue
+	tenants  map[string]*fairTenant
+	cfg      fairLimiterConfig
+
+	queueLength gofuncyconv.LimiterQueueLength
+}
+
+type fairTenant struct {
+	finish  float64
+	waiting int
+}
+
+type fairWaiter struct {
+	n        int64
+	tenant   string
+	priority int
+	start    float64
+	finish   float64
+	seq      uint64
+	index    int
+	ready    chan struct{}
+}
+
+// NewFairLimiter creates a new FairLimiter admitting up to capacity
+// concurrent executions.
+func NewFairLimiter(capacity int64, opts ...FairLimiterOption) *FairLimiter {
+	l := &FairLimiter{
+		capacity: capacity,
+		tenants:  map[string]*fairTenant{},
+		cfg: fairLimiterConfig{
+			name:       "gofuncy.limiter",
+			tenantFn:   TenantFromContext,
+			priorityFn: PriorityFromContext,
+			weightFn: func(string) float64 {
+				return 1
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&l.cfg)
+	}
+
+	mp := l.cfg.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	m := mp.Meter(ScopeName, metric.WithSchemaURL(otelsemconv.SchemaURL))
+
+	var err error
+
+	l.queueLength, err = gofuncyconv.NewLimiterQueueLength(m)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return l
+}
+
+// Acquire blocks until n slots are available and the caller's tenant and
+// priority class are next in line, or ctx is done.
+func (l *FairLimiter) Acquire(ctx context.Context, n int64) error {
+	tenant, priority := l.cfg.tenantFn(ctx), l.cfg.priorityFn(ctx)
+	attrs := []attribute.KeyValue{semconv.LimiterTenant(tenant), semconv.LimiterPriority(priority)}
+
+	l.mu.Lock()
+
+	if l.queue.Len() == 0 && l.fits(n) {
+		l.inFlight += n
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	w := l.enqueue(n, tenant, priority)
+	l.mu.Unlock()
+
+	l.queueLength.Add(ctx, 1, l.cfg.name, attrs...)
+	defer l.queueLength.Add(ctx, -1, l.cfg.name, attrs...)
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+
+		select {
+		case <-w.ready:
+			// acquired after cancellation; give the slots back
+			l.inFlight -= n
+		default:
+			heap.Remove(&l.queue, w.index)
+			l.dequeued(w)
+		}
+
+		l.notify()
+		l.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+func (l *FairLimiter) waitAttributes(ctx context.Context) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.LimiterName(l.cfg.name),
+		semconv.LimiterTenant(l.cfg.tenantFn(ctx)),
+		semconv.LimiterPriority(l.cfg.priorityFn(ctx)),
+	}
+}
+
+// TryAcquire acquires n slots without blocking and reports whether it
+// succeeded. It fails while callers are queued, so it never jumps the queue.
+func (l *FairLimiter) TryAcquire(n int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.queue.Len() == 0 && l.fits(n) {
+		l.inFlight += n
+		return true
+	}
+
+	return false
+}
+
+// Release returns n slots and admits queued waiters that fit the capacity.
+func (l *FairLimiter) Release(n int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight -= n
+	if l.inFlight < 0 {
+		panic("gofuncy: fair limiter released more than held")
+	}
+
+	l.notify()
+}
+
+// Capacity returns the maximum number of concurrent executions.
+func (l *FairLimiter) Capacity() int {
+	return int(l.capacity)
+}
+
+// InFlight returns the number of currently admitted executions.
+func (l *FairLimiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.inFlight)
+}
+
+// Queued returns the number of callers waiting to be admitted.
+func (l *FairLimiter) Queued() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.queue.Len()
+}
+
+// fits reports whether n more slots fit the capacity. A single request larger
+// than the capacity is admitted when nothing is in flight so it cannot block
+// forever. Must be called while l.mu is held.
+func (l *FairLimiter) fits(n int64) bool {
+	return l.inFlight+n <= l.capacity || l.inFlight == 0
+}
+
+// enqueue tags a new waiter with its virtual start and finish time and pushes
+// it onto the queue. Must be called while l.mu is held.
+func (l *FairLimiter) enqueue(n int64, tenant string, priority int) *fairWaiter {
+	t, ok := l.tenants[tenant]
+	if !ok {
+		t = &fairTenant{}
+		l.tenants[tenant] = t
+	}
+
+	weight := l.cfg.weightFn(tenant)
+	if weight <= 0 {
+		weight = 1
+	}
+
+	l.seq++
+
+	w := &fairWaiter{
+		n:        n,
+		tenant:   tenant,
+		priority: priority,
+		start:    max(l.vtime, t.finish),
+		seq:      l.seq,
+		ready:    make(chan struct{}),
+	}
+	w.finish = w.start + float64(n)/weight
+
+	t.finish = w.finish
+	t.waiting++
+
+	heap.Push(&l.queue, w)
+
+	return w
+}
+
+// dequeued updates the bookkeeping of w's tenant after w left the queue and
+// forgets tenants without waiters that no longer carry a lead. Must be called
+// while l.mu is held.
+func (l *FairLimiter) dequeued(w *fairWaiter) {
+	if l.queue.Len() == 0 {
+		// idle: fairness history is no longer relevant
+		clear(l.tenants)
+		l.vtime = 0
+
+		return
+	}
+
+	if t, ok := l.tenants[w.tenant]; ok {
+		t.waiting--
+		if t.waiting == 0 && t.finish <= l.vtime {
+			delete(l.tenants, w.tenant)
+		}
+	}
+}
+
+// notify admits queued waiters in priority and fair-share order while they
+// fit. Must be called while l.mu is held.
+func (l *FairLimiter) notify() {
+	for l.queue.Len() > 0 {
+		w := l.queue[0]
+		if !l.fits(w.n) {
+			return
+		}
+
+		heap.Pop(&l.queue)
+
+		l.inFlight += w.n
+		l.vtime = max(l.vtime, w.start)
+		l.dequeued(w)
+
+		close(w.ready)
+	}
+}
+
+// FairLimiterName sets the name reported with the limiter's metrics.
+// Defaults to "gofuncy.limiter".
+func FairLimiterName(name string) FairLimiterOption {
+	return func(c *fairLimiterConfig) {
+		c.name = name
+	}
+}
+
+// FairLimiterTenant sets the function that extracts the tenant key from the
+// context passed to Acquire. Defaults to TenantFromContext. The tenant is
+// recorded as a metric attribute, so keep its cardinality bounded.
+func FairLimiterTenant(fn func(ctx context.Context) string) FairLimiterOption {
+	return func(c *fairLimiterConfig) {
+		c.tenantFn = fn
+	}
+}
+
+// FairLimiterPriority sets the function that extracts the priority class from
+// the context passed to Acquire. Higher values are admitted first.
+// Defaults to PriorityFromContext.
+func FairLimiterPriority(fn func(ctx context.Context) int) FairLimiterOption {
+	return func(c *fairLimiterConfig) {
+		c.priorityFn = fn
+	}
+}
+
+// FairLimiterWeights sets the function that returns the share of a tenant
+// within its priority class. A tenant with weight 2 is admitted twice as often
+// as a tenant with weight 1 while both are waiting. Defaults to 1 for all
+// tenants; non-positive weights are treated as 1.
+func FairLimiterWeights(fn func(tenant string) float64) FairLimiterOption {
+	return func(c *fairLimiterConfig) {
+		c.weightFn = fn
+	}
+}
+
+// FairLimiterMeterProvider sets a custom meter provider for the metrics.
+func FairLimiterMeterProvider(mp metric.MeterProvider) FairLimiterOption {
+	return func(c *fairLimiterConfig) {
+		c.meterProvider = mp
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ fairQueue
+// ------------------------------------------------------------------------------------------------
+
+// fairQueue is a heap of waiters ordered by priority (descending), virtual
+// finish time and arrival.
+type fairQueue []*fairWaiter
+
+func (q fairQueue) Len() int { return len(q) }
+
+func (q fairQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	if q[i].finish != q[j].finish {
+		return q[i].finish < q[j].finish
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q fairQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *fairQueue) Push(x any) {
+	w := x.(*fairWaiter) //nolint:forcetypeassert
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *fairQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+
+	return w
+}
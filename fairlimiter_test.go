@@ -0,0 +1,181 @@
+package gofuncy_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// admissionOrder queues one Acquire per context while the limiter's single
+// slot is held, then releases it and returns the order in which the callers
+// were admitted.
+func admissionOrder(t *testing.T, l *gofuncy.FairLimiter, ctxs []context.Context) []int {
+	t.Helper()
+
+	require.NoError(t, l.Acquire(t.Context(), 1))
+
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+
+	for i, ctx := range ctxs {
+		wg.Go(func() {
+			if err := l.Acquire(ctx, 1); err != nil {
+				return
+			}
+
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+
+			l.Release(1)
+		})
+
+		// enqueue sequentially so arrival order is deterministic
+		require.Eventually(t, func() bool { return l.Queued() == i+1 }, time.Second, time.Millisecond)
+	}
+
+	l.Release(1)
+	wg.Wait()
+
+	return order
+}
+
+func TestFairLimiter_boundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	l := gofuncy.NewFairLimiter(2, gofuncy.FairLimiterMeterProvider(mp))
+
+	var active, peak atomic.Int32
+
+	g := gofuncy.NewGroup(gofuncy.ContextWithTenant(t.Context(), "a"), gofuncy.WithLimiter(l))
+
+	for range 10 {
+		g.Add(func(ctx context.Context) error {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			active.Add(-1)
+
+			return nil
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Zero(t, l.InFlight())
+	assert.Zero(t, l.Queued())
+}
+
+func TestFairLimiter_priority(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewFairLimiter(1)
+
+	ctxs := []context.Context{
+		gofuncy.ContextWithPriority(t.Context(), 0),
+		gofuncy.ContextWithPriority(t.Context(), 1),
+		gofuncy.ContextWithPriority(t.Context(), 2),
+		gofuncy.ContextWithPriority(t.Context(), 1),
+	}
+
+	assert.Equal(t, []int{2, 1, 3, 0}, admissionOrder(t, l, ctxs))
+}
+
+func TestFairLimiter_fairAcrossTenants(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewFairLimiter(1)
+
+	a := gofuncy.ContextWithTenant(t.Context(), "a")
+	b := gofuncy.ContextWithTenant(t.Context(), "b")
+
+	// tenant a submits a burst before tenant b
+	ctxs := []context.Context{a, a, a, a, b, b}
+
+	assert.Equal(t, []int{0, 4, 1, 5, 2, 3}, admissionOrder(t, l, ctxs))
+}
+
+func TestFairLimiter_weights(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewFairLimiter(1, gofuncy.FairLimiterWeights(func(tenant string) float64 {
+		if tenant == "gold" {
+			return 2
+		}
+
+		return 1
+	}))
+
+	gold := gofuncy.ContextWithTenant(t.Context(), "gold")
+	basic := gofuncy.ContextWithTenant(t.Context(), "basic")
+
+	ctxs := []context.Context{basic, basic, basic, gold, gold, gold, gold}
+
+	assert.Equal(t, []int{3, 0, 4, 5, 1, 6, 2}, admissionOrder(t, l, ctxs))
+}
+
+func TestFairLimiter_customExtractors(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+
+	l := gofuncy.NewFairLimiter(1,
+		gofuncy.FairLimiterTenant(func(ctx context.Context) string {
+			s, _ := ctx.Value(key{}).(string)
+			return s
+		}),
+		gofuncy.FairLimiterPriority(func(ctx context.Context) int {
+			if s, _ := ctx.Value(key{}).(string); s == "admin" {
+				return 1
+			}
+
+			return 0
+		}),
+	)
+
+	ctxs := []context.Context{
+		context.WithValue(t.Context(), key{}, "user"),
+		context.WithValue(t.Context(), key{}, "admin"),
+	}
+
+	assert.Equal(t, []int{1, 0}, admissionOrder(t, l, ctxs))
+}
+
+func TestFairLimiter_acquireRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewFairLimiter(1)
+
+	require.NoError(t, l.Acquire(t.Context(), 1))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		return nil
+	}, gofuncy.WithLimiter(l))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, l.Queued())
+
+	l.Release(1)
+	assert.Zero(t, l.InFlight())
+}
@@ -3,6 +3,11 @@ package gofuncy
 import (
 	"context"
 	"errors"
+
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/foomo/gofuncy/semconv"
 )
 
 // FallbackOption configures fallback behavior.
@@ -10,6 +15,7 @@ type FallbackOption func(*fallbackConfig)
 
 type fallbackConfig struct {
 	fallbackIf func(error) bool
+	tracing    bool
 }
 
 // Fallback returns a Middleware that calls fn when the wrapped function returns
@@ -36,6 +42,12 @@ func Fallback(fn func(ctx context.Context, err error) error, opts ...FallbackOpt
 				return err
 			}
 
+			if cfg.tracing {
+				trace.SpanFromContext(ctx).AddEvent(semconv.EventFallback, trace.WithAttributes(
+					otelsemconv.ExceptionMessage(err.Error()),
+				))
+			}
+
 			return fn(ctx, err)
 		}
 	}
@@ -50,6 +62,14 @@ func FallbackIf(fn func(error) bool) FallbackOption {
 	}
 }
 
+// fallbackWithTracing records a span event on the current span whenever the
+// fallback is invoked.
+func fallbackWithTracing() FallbackOption {
+	return func(c *fallbackConfig) {
+		c.tracing = true
+	}
+}
+
 func defaultFallbackIf(err error) bool {
 	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 		return false
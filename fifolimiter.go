@@ -0,0 +1,167 @@
+package gofuncy
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// fifoLimiter admits executions up to a limit and queues the rest in FIFO
+// order. It is the core of AdaptiveLimiter and ResizableLimiter, which embed
+// it and change limit while holding mu. A limit of 0 or less means no limit.
+type fifoLimiter struct {
+	mu       sync.Mutex
+	limit    int64
+	inFlight int64
+	waiters  list.List
+}
+
+type limiterWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// Acquire blocks until n slots are available under the current limit or ctx
+// is done. Waiters are served in FIFO order.
+func (l *fifoLimiter) Acquire(ctx context.Context, n int64) error {
+	l.mu.Lock()
+
+	if l.waiters.Len() == 0 && l.fits(n) {
+		l.inFlight += n
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	w := &limiterWaiter{n: n, ready: make(chan struct{})}
+	el := l.waiters.PushBack(w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+
+		select {
+		case <-w.ready:
+			// acquired after cancellation; give the slots back
+			l.inFlight -= n
+			l.notify()
+		default:
+			l.waiters.Remove(el)
+			l.notify()
+		}
+
+		l.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires n slots without blocking and reports whether it
+// succeeded. It fails while callers are queued, so it never jumps the queue.
+func (l *fifoLimiter) TryAcquire(n int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.waiters.Len() == 0 && l.fits(n) {
+		l.inFlight += n
+		return true
+	}
+
+	return false
+}
+
+// Release returns n slots and admits queued waiters that fit the limit.
+func (l *fifoLimiter) Release(n int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight -= n
+	if l.inFlight < 0 {
+		panic("gofuncy: limiter released more than held")
+	}
+
+	l.notify()
+}
+
+// InFlight returns the number of currently admitted executions.
+func (l *fifoLimiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.inFlight)
+}
+
+// fits reports whether n more slots fit under the current limit. A single
+// request larger than the limit is admitted when nothing is in flight so it
+// cannot block forever. Must be called while l.mu is held.
+func (l *fifoLimiter) fits(n int64) bool {
+	return l.limit <= 0 || l.inFlight+n <= l.limit || l.inFlight == 0
+}
+
+// notify admits queued waiters in FIFO order while they fit.
+// Must be called while l.mu is held.
+func (l *fifoLimiter) notify() {
+	for {
+		front := l.waiters.Front()
+		if front == nil {
+			return
+		}
+
+		w := front.Value.(*limiterWaiter) //nolint:forcetypeassert
+		if !l.fits(w.n) {
+			return
+		}
+
+		l.inFlight += w.n
+		l.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// registerGauges registers the limit and in-flight gauges of the limiter
+// under name and returns their registration, or nil if it failed.
+func (l *fifoLimiter) registerGauges(mp metric.MeterProvider, name string) metric.Registration {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	m := mp.Meter(ScopeName, metric.WithSchemaURL(otelsemconv.SchemaURL))
+
+	limitGauge, err := gofuncyconv.NewLimiterLimit(m)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+
+	inFlightGauge, err := gofuncyconv.NewLimiterInFlight(m)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+
+	reg, err := m.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		l.mu.Lock()
+		limit, inFlight := max(l.limit, 0), l.inFlight
+		l.mu.Unlock()
+
+		limitGauge.Observe(o, limit, name)
+		inFlightGauge.Observe(o, inFlight, name)
+
+		return nil
+	}, limitGauge.Inst(), inFlightGauge.Inst())
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+
+	return reg
+}
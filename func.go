@@ -14,6 +14,7 @@ type Middleware func(Func) Func
 // Safe to call multiple times.
 type ReadyFunc func()
 
-// StopFunc cancels a goroutine's context, signaling it to shut down.
+// StopFunc cancels a goroutine's context with ErrStopped as the cause,
+// signaling it to shut down.
 // Safe to call multiple times.
 type StopFunc func()
@@ -0,0 +1,323 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrFutureTimeout is returned by a future created via Timeout when the
+	// underlying future did not complete in time.
+	ErrFutureTimeout = errors.New("future timed out")
+	// ErrNoFutures is returned by AnyOf and Race when called without futures.
+	ErrNoFutures = errors.New("no futures")
+)
+
+// Future holds the result of an asynchronous computation. Create one via
+// Async, or derive one via Then, MapFuture, Timeout, AllOf, AnyOf and Race.
+// All methods are safe for concurrent use.
+type Future[T any] struct {
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+	done   chan struct{}
+	value  T
+	err    error
+}
+
+// Async spawns fn in a goroutine with the full middleware chain, like Wait,
+// and returns a Future for its result. The goroutine runs with a context that
+// is cancelled by Future.Cancel, so combinators can stop losing branches.
+// Use WithName to set a custom metric/tracing label; defaults to "gofuncy.async".
+func Async[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...GoOption) *Future[T] {
+	o := newGoOptions(opts)
+	if o.name == "" {
+		o.name = "gofuncy.async"
+	}
+
+	slot := newResultSlot[T]()
+	o.result = slot
+
+	inner := Func(func(ctx context.Context) error {
+		v, err := fn(ctx)
+		slot.target(ctx).value = v
+
+		return err
+	})
+
+	run := withContextInjection(inner, o.name)
+	run = buildChain(run, &o, "gofuncy.async", o.callerSkip+3)
+
+	fctx, cancel := context.WithCancel(ctx)
+	f := newFuture[T](ctx, cancel)
+
+	if err := admit(ctx, &o); err != nil {
+		cancel()
+
+		var zero T
+
+		f.resolve(zero, err)
+
+		return f
+	}
+
+	go func() {
+		defer cancel()
+
+		if o.limiter != nil {
+			defer o.limiter.Release(o.limiterWeight())
+		}
+
+		if err := run(fctx); err != nil {
+			v, _ := slot.value.(T)
+			f.resolve(v, err)
+
+			return
+		}
+
+		f.resolve(resultValue[T](slot))
+	}()
+
+	return f
+}
+
+// Await blocks until the future completes or ctx is done. Cancelling ctx only
+// stops waiting; use Cancel to stop the computation.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed when the future completes.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel cancels the context of the computation. It does not wait for the
+// future to complete and is a no-op if it already has.
+func (f *Future[T]) Cancel() {
+	f.cancel()
+}
+
+// Then returns a Future that runs fn via DoValue with the value of f once f
+// succeeds, so fn gets the full middleware chain. If f fails, fn is not called
+// and the returned future fails with the same error. Cancelling the returned
+// future also cancels f. Use WithName to set a custom metric/tracing label;
+// defaults to "gofuncy.then".
+func Then[T, R any](f *Future[T], fn func(ctx context.Context, value T) (R, error), opts ...GoOption) *Future[R] {
+	ctx, cancel := context.WithCancel(f.ctx)
+	out := newFuture[R](f.ctx, func() {
+		cancel()
+		f.Cancel()
+	})
+
+	doOpts := make([]GoOption, 0, len(opts)+1)
+	doOpts = append(doOpts, WithName("gofuncy.then"))
+	doOpts = append(doOpts, opts...)
+
+	go func() {
+		defer cancel()
+
+		v, err := f.Await(ctx)
+		if err != nil {
+			var zero R
+
+			out.resolve(zero, err)
+
+			return
+		}
+
+		out.resolve(DoValue(ctx, func(ctx context.Context) (R, error) {
+			return fn(ctx, v)
+		}, doOpts...))
+	}()
+
+	return out
+}
+
+// MapFuture returns a Future with the value of f transformed by fn. If f
+// fails, the returned future fails with the same error. Cancelling the
+// returned future cancels f.
+func MapFuture[T, R any](f *Future[T], fn func(value T) R) *Future[R] {
+	out := newFuture[R](f.ctx, f.Cancel)
+
+	go func() {
+		<-f.done
+
+		if f.err != nil {
+			var zero R
+
+			out.resolve(zero, f.err)
+
+			return
+		}
+
+		out.resolve(fn(f.value), nil)
+	}()
+
+	return out
+}
+
+// Timeout returns a Future that completes like f, or fails with
+// ErrFutureTimeout and cancels f if f does not complete within d.
+func Timeout[T any](f *Future[T], d time.Duration) *Future[T] {
+	out := newFuture[T](f.ctx, f.Cancel)
+
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-f.done:
+			out.resolve(f.value, f.err)
+		case <-timer.C:
+			f.Cancel()
+
+			var zero T
+
+			out.resolve(zero, ErrFutureTimeout)
+		}
+	}()
+
+	return out
+}
+
+// AllOf returns a Future with the values of all futures in order. It fails
+// with the first error, cancelling the remaining futures, or with the context
+// error if ctx is done first.
+func AllOf[T any](ctx context.Context, futures ...*Future[T]) *Future[[]T] {
+	out := newFuture[[]T](ctx, cancelAll(futures))
+
+	go func() {
+		values := make([]T, len(futures))
+		completed := awaitEach(futures)
+
+		for range futures {
+			select {
+			case i := <-completed:
+				if futures[i].err != nil {
+					out.cancel()
+					out.resolve(nil, futures[i].err)
+
+					return
+				}
+
+				values[i] = futures[i].value
+			case <-ctx.Done():
+				out.cancel()
+				out.resolve(nil, ctx.Err())
+
+				return
+			}
+		}
+
+		out.resolve(values, nil)
+	}()
+
+	return out
+}
+
+// AnyOf returns a Future with the value of the first future that succeeds,
+// cancelling the others. If all futures fail, it fails with all errors joined.
+func AnyOf[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	out := newFuture[T](ctx, cancelAll(futures))
+
+	go func() {
+		var zero T
+
+		if len(futures) == 0 {
+			out.resolve(zero, ErrNoFutures)
+			return
+		}
+
+		errs := make([]error, len(futures))
+		completed := awaitEach(futures)
+
+		for range futures {
+			select {
+			case i := <-completed:
+				if futures[i].err == nil {
+					out.cancel()
+					out.resolve(futures[i].value, nil)
+
+					return
+				}
+
+				errs[i] = futures[i].err
+			case <-ctx.Done():
+				out.cancel()
+				out.resolve(zero, ctx.Err())
+
+				return
+			}
+		}
+
+		out.resolve(zero, errors.Join(errs...))
+	}()
+
+	return out
+}
+
+// Race returns a Future that completes like the first future to complete,
+// whether it succeeded or failed, cancelling the others.
+func Race[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	out := newFuture[T](ctx, cancelAll(futures))
+
+	go func() {
+		var zero T
+
+		if len(futures) == 0 {
+			out.resolve(zero, ErrNoFutures)
+			return
+		}
+
+		select {
+		case i := <-awaitEach(futures):
+			out.cancel()
+			out.resolve(futures[i].value, futures[i].err)
+		case <-ctx.Done():
+			out.cancel()
+			out.resolve(zero, ctx.Err())
+		}
+	}()
+
+	return out
+}
+
+func newFuture[T any](ctx context.Context, cancel context.CancelFunc) *Future[T] {
+	return &Future[T]{ctx: ctx, cancel: cancel, done: make(chan struct{})}
+}
+
+// resolve stores the result and completes the future. It must be called exactly once.
+func (f *Future[T]) resolve(value T, err error) {
+	f.value, f.err = value, err
+	close(f.done)
+}
+
+// awaitEach returns a channel receiving the index of each future as it completes.
+func awaitEach[T any](futures []*Future[T]) <-chan int {
+	completed := make(chan int, len(futures))
+
+	for i, f := range futures {
+		go func() {
+			<-f.done
+			completed <- i
+		}()
+	}
+
+	return completed
+}
+
+func cancelAll[T any](futures []*Future[T]) context.CancelFunc {
+	return func() {
+		for _, f := range futures {
+			f.Cancel()
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleAsync() {
+	ctx := context.Background()
+
+	f := gofuncy.Async(ctx, func(ctx context.Context) (int, error) {
+		return 21, nil
+	})
+
+	doubled := gofuncy.MapFuture(f, func(v int) int { return v * 2 })
+
+	v, err := doubled.Await(ctx)
+
+	fmt.Println(v, err)
+	// Output:
+	// 42 <nil>
+}
+
+// sleepy returns a future that resolves to v after d, or fails when cancelled.
+func sleepy[T any](ctx context.Context, d time.Duration, v T, err error) *gofuncy.Future[T] {
+	return gofuncy.Async(ctx, func(ctx context.Context) (T, error) {
+		select {
+		case <-time.After(d):
+			return v, err
+		case <-ctx.Done():
+			var zero T
+
+			return zero, ctx.Err()
+		}
+	})
+}
+
+func TestAsync(t *testing.T) {
+	t.Parallel()
+
+	f := gofuncy.Async(t.Context(), func(ctx context.Context) (string, error) {
+		return "ok", nil
+	})
+
+	v, err := f.Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "ok", v)
+
+	// awaiting again returns the same result
+	v, err = f.Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "ok", v)
+}
+
+func TestAsync_panic(t *testing.T) {
+	t.Parallel()
+
+	f := gofuncy.Async(t.Context(), func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+
+	_, err := f.Await(t.Context())
+
+	var panicErr *gofuncy.PanicError
+	require.ErrorAs(t, err, &panicErr)
+}
+
+func TestAsync_cancel(t *testing.T) {
+	t.Parallel()
+
+	f := sleepy(t.Context(), time.Minute, 1, nil)
+	f.Cancel()
+
+	_, err := f.Await(t.Context())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFuture_awaitRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	f := sleepy(t.Context(), 50*time.Millisecond, 1, nil)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+
+	_, err := f.Await(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	v, err := f.Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestThen(t *testing.T) {
+	t.Parallel()
+
+	f := gofuncy.Then(sleepy(t.Context(), time.Millisecond, 21, nil), func(ctx context.Context, v int) (string, error) {
+		return strconv.Itoa(v * 2), nil
+	})
+
+	v, err := f.Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "42", v)
+}
+
+func TestThen_skipsOnError(t *testing.T) {
+	t.Parallel()
+
+	called := false
+
+	f := gofuncy.Then(sleepy(t.Context(), time.Millisecond, 0, errors.New("boom")), func(ctx context.Context, v int) (int, error) {
+		called = true
+		return v, nil
+	})
+
+	_, err := f.Await(t.Context())
+	require.EqualError(t, err, "boom")
+	assert.False(t, called)
+}
+
+func TestMapFuture_propagatesError(t *testing.T) {
+	t.Parallel()
+
+	f := gofuncy.MapFuture(sleepy(t.Context(), time.Millisecond, 1, errors.New("boom")), func(v int) int { return v + 1 })
+
+	_, err := f.Await(t.Context())
+	require.EqualError(t, err, "boom")
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	slow := sleepy(t.Context(), time.Minute, 1, nil)
+
+	_, err := gofuncy.Timeout(slow, 5*time.Millisecond).Await(t.Context())
+	require.ErrorIs(t, err, gofuncy.ErrFutureTimeout)
+
+	_, err = slow.Await(t.Context())
+	require.ErrorIs(t, err, context.Canceled)
+
+	v, err := gofuncy.Timeout(sleepy(t.Context(), time.Millisecond, 2, nil), time.Second).Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestAllOf(t *testing.T) {
+	t.Parallel()
+
+	f := gofuncy.AllOf(t.Context(),
+		sleepy(t.Context(), 10*time.Millisecond, 1, nil),
+		sleepy(t.Context(), time.Millisecond, 2, nil),
+		sleepy(t.Context(), 5*time.Millisecond, 3, nil),
+	)
+
+	v, err := f.Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, v)
+}
+
+func TestAllOf_failsFast(t *testing.T) {
+	t.Parallel()
+
+	slow := sleepy(t.Context(), time.Minute, 1, nil)
+
+	_, err := gofuncy.AllOf(t.Context(),
+		slow,
+		sleepy(t.Context(), time.Millisecond, 0, errors.New("boom")),
+	).Await(t.Context())
+	require.EqualError(t, err, "boom")
+
+	_, err = slow.Await(t.Context())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAnyOf(t *testing.T) {
+	t.Parallel()
+
+	slow := sleepy(t.Context(), time.Minute, "slow", nil)
+
+	v, err := gofuncy.AnyOf(t.Context(),
+		sleepy(t.Context(), time.Millisecond, "", errors.New("boom")),
+		slow,
+		sleepy(t.Context(), 5*time.Millisecond, "fast", nil),
+	).Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "fast", v)
+
+	_, err = slow.Await(t.Context())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAnyOf_allFail(t *testing.T) {
+	t.Parallel()
+
+	errA, errB := errors.New("a"), errors.New("b")
+
+	_, err := gofuncy.AnyOf(t.Context(),
+		sleepy(t.Context(), time.Millisecond, 0, errA),
+		sleepy(t.Context(), time.Millisecond, 0, errB),
+	).Await(t.Context())
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errB)
+
+	_, err = gofuncy.AnyOf[int](t.Context()).Await(t.Context())
+	require.ErrorIs(t, err, gofuncy.ErrNoFutures)
+}
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	slow := sleepy(t.Context(), time.Minute, 1, nil)
+
+	_, err := gofuncy.Race(t.Context(),
+		slow,
+		sleepy(t.Context(), time.Millisecond, 0, errors.New("boom")),
+	).Await(t.Context())
+	require.EqualError(t, err, "boom")
+
+	_, err = slow.Await(t.Context())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFuture_tracing(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	f := gofuncy.Async(t.Context(), func(ctx context.Context) (int, error) {
+		return 1, nil
+	}, gofuncy.WithName("load"), gofuncy.WithTracerProvider(tp))
+
+	g := gofuncy.Then(f, func(ctx context.Context, v int) (int, error) {
+		return v + 1, nil
+	}, gofuncy.WithName("enrich"), gofuncy.WithTracerProvider(tp))
+
+	v, err := g.Await(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+	findSpan(t, spans, "gofuncy.async load")
+	findSpan(t, spans, "gofuncy.do enrich")
+}
@@ -0,0 +1,190 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/foomo/gofuncy/semconv"
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// ErrGatherTimeout is the error of items that had not completed by the soft
+// deadline of Gather.
+var ErrGatherTimeout = errors.New("gather deadline exceeded")
+
+// Outcome is the outcome of a single item of Gather.
+type Outcome[R any] struct {
+	// Value is the result of the item, if it completed.
+	Value R
+	// Err is the error of the item, or ErrGatherTimeout if it timed out.
+	Err error
+	// TimedOut reports whether the item had not completed by the soft deadline.
+	TimedOut bool
+}
+
+// Gather runs fn for each item concurrently like Map, but instead of failing as
+// a whole returns the outcome of each item, in input order, once all items
+// completed or the soft deadline d elapsed, whichever comes first. Items still
+// running at the deadline are reported as timed out and cancelled, unless
+// WithKeepStragglers is set; Gather does not wait for them to return. Items
+// not started by the deadline are never started. The fraction of items that
+// completed, successfully or not, is recorded as the
+// gofuncy.groups.gather.coverage metric and the gofuncy.gather.coverage span
+// attribute.
+// All GroupOption options apply (WithLimit, WithFailFast, telemetry, etc.).
+// Use WithName to set a custom metric/tracing label; defaults to "gofuncy.gather".
+func Gather[T, R any](ctx context.Context, items []T, d time.Duration, fn func(ctx context.Context, item T) (R, error), opts ...GroupOption) []Outcome[R] {
+	if len(items) == 0 {
+		return nil
+	}
+
+	groupOpts := make([]GroupOption, 0, len(opts)+1)
+	groupOpts = append(groupOpts, WithName("gofuncy.gather"))
+	groupOpts = append(groupOpts, opts...)
+
+	gctx, cancel := context.WithCancel(ctx)
+
+	g := NewGroup(gctx, groupOpts...)
+	s := &gather[R]{
+		g:         g,
+		outcomes:  make([]Outcome[R], len(items)),
+		completed: make([]bool, len(items)),
+		pending:   len(items),
+	}
+
+	// items that were never admitted have not completed when Wait returns;
+	// finish before the group span ends, so its attributes are recorded
+	g.beforeEnd = func() { s.finish(ctx, false, g.errs) }
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer cancel()
+
+		for i, item := range items {
+			// items are not added once the deadline fired
+			if !s.running() {
+				break
+			}
+
+			g.Add(func(ctx context.Context) error {
+				// admitted after the deadline, e.g. with WithKeepStragglers
+				// and WithLimit; its outcome is already reported
+				if !s.running() {
+					return ErrGatherTimeout
+				}
+
+				v, err := fn(ctx, item)
+				s.complete(ctx, i, v, err)
+
+				return err
+			})
+		}
+
+		_ = g.Wait() //nolint:contextcheck
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return s.finish(ctx, false, nil)
+	case <-timer.C:
+		// stragglers are cancelled but not waited for; the group winds down
+		// in the background
+		if !g.o.keepStragglers {
+			cancel()
+		}
+
+		return s.finish(ctx, true, nil)
+	}
+}
+
+// gather tracks the outcomes of Gather until it finishes.
+type gather[R any] struct {
+	g         *Group
+	mu        sync.Mutex
+	outcomes  []Outcome[R]
+	completed []bool
+	pending   int
+	finished  bool
+}
+
+// running reports whether Gather has not finished yet.
+func (s *gather[R]) running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return !s.finished
+}
+
+func (s *gather[R]) complete(ctx context.Context, i int, v R, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return
+	}
+
+	s.outcomes[i] = Outcome[R]{Value: v, Err: err}
+	s.completed[i] = true
+	s.pending--
+
+	// finish while the last item runs, so the group span is still recording
+	if s.pending == 0 {
+		s.finishLocked(ctx, false, nil)
+	}
+}
+
+// finish returns the outcomes, marking pending items as timed out, or as
+// failed with their group error if timedOut is false.
+func (s *gather[R]) finish(ctx context.Context, timedOut bool, errs []error) []Outcome[R] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.finished {
+		s.finishLocked(ctx, timedOut, errs)
+	}
+
+	return s.outcomes
+}
+
+func (s *gather[R]) finishLocked(ctx context.Context, timedOut bool, errs []error) {
+	s.finished = true
+
+	completed, timedOutItems := 0, 0
+
+	for i, ok := range s.completed {
+		switch {
+		case ok:
+			completed++
+		case timedOut:
+			s.outcomes[i] = Outcome[R]{Err: ErrGatherTimeout, TimedOut: true}
+			timedOutItems++
+		case i < len(errs):
+			s.outcomes[i] = Outcome[R]{Err: errs[i]}
+		}
+	}
+
+	coverage := float64(completed) / float64(len(s.outcomes))
+
+	if s.g.span != nil {
+		s.g.span.SetAttributes(
+			semconv.GatherCoverage(coverage),
+			semconv.GatherTimedOut(timedOutItems),
+		)
+	}
+
+	gatherCoverage, err := gofuncyconv.NewGroupsGatherCoverage(s.g.o.meter())
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	gatherCoverage.Record(context.WithoutCancel(ctx), coverage, s.g.o.name)
+}
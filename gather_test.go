@@ -0,0 +1,291 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/semconv"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/sync/semaphore"
+)
+
+func ExampleGather() {
+	ctx := context.Background()
+
+	shards := []time.Duration{time.Millisecond, time.Minute}
+
+	outcomes := gofuncy.Gather(ctx, shards, 50*time.Millisecond, func(ctx context.Context, d time.Duration) (string, error) {
+		select {
+		case <-time.After(d):
+			return "hit", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+
+	for _, o := range outcomes {
+		fmt.Println(o.Value, o.TimedOut)
+	}
+	// Output:
+	// hit false
+	//  true
+}
+
+// shard returns the item after the given delay, or fails when cancelled.
+func shard(ctx context.Context, d time.Duration) (time.Duration, error) {
+	select {
+	case <-time.After(d):
+		return d, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func TestGather(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+
+	outcomes := gofuncy.Gather(t.Context(), []int{1, 2, 3}, time.Second, func(ctx context.Context, i int) (int, error) {
+		if i == 2 {
+			return 0, boom
+		}
+
+		return i * 10, nil
+	})
+
+	require.Len(t, outcomes, 3)
+	assert.Equal(t, gofuncy.Outcome[int]{Value: 10}, outcomes[0])
+	assert.Equal(t, gofuncy.Outcome[int]{Err: boom}, outcomes[1])
+	assert.Equal(t, gofuncy.Outcome[int]{Value: 30}, outcomes[2])
+
+	assert.Nil(t, gofuncy.Gather(t.Context(), []int{}, time.Second, func(ctx context.Context, i int) (int, error) {
+		return i, nil
+	}))
+}
+
+func TestGather_softDeadline(t *testing.T) {
+	t.Parallel()
+
+	var cancelled atomic.Bool
+
+	start := time.Now()
+
+	outcomes := gofuncy.Gather(t.Context(), []time.Duration{time.Millisecond, time.Minute}, 20*time.Millisecond,
+		func(ctx context.Context, d time.Duration) (time.Duration, error) {
+			v, err := shard(ctx, d)
+			if errors.Is(err, context.Canceled) {
+				cancelled.Store(true)
+			}
+
+			return v, err
+		},
+	)
+
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, outcomes, 2)
+	assert.Equal(t, gofuncy.Outcome[time.Duration]{Value: time.Millisecond}, outcomes[0])
+	assert.True(t, outcomes[1].TimedOut)
+	require.ErrorIs(t, outcomes[1].Err, gofuncy.ErrGatherTimeout)
+	assert.Eventually(t, cancelled.Load, time.Second, time.Millisecond, "straggler must be cancelled")
+}
+
+func TestGather_softDeadlineDoesNotWait(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+
+	outcomes := gofuncy.Gather(t.Context(), []int{1}, 10*time.Millisecond,
+		func(ctx context.Context, item int) (int, error) {
+			<-release // ignores cancellation
+			return item, nil
+		},
+	)
+
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, outcomes, 1)
+	assert.True(t, outcomes[0].TimedOut)
+}
+
+func TestGather_keepStragglers(t *testing.T) {
+	t.Parallel()
+
+	finished := make(chan error, 1)
+
+	outcomes := gofuncy.Gather(t.Context(), []time.Duration{time.Millisecond, 50 * time.Millisecond}, 10*time.Millisecond,
+		func(ctx context.Context, d time.Duration) (time.Duration, error) {
+			v, err := shard(ctx, d)
+			if d > 10*time.Millisecond {
+				finished <- err
+			}
+
+			return v, err
+		},
+		gofuncy.WithKeepStragglers(),
+	)
+
+	require.Len(t, outcomes, 2)
+	assert.False(t, outcomes[0].TimedOut)
+	assert.True(t, outcomes[1].TimedOut)
+
+	// the straggler completes in the background
+	require.NoError(t, <-finished)
+	assert.True(t, outcomes[1].TimedOut, "late results must not change the outcomes")
+}
+
+func TestGather_telemetry(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	outcomes := gofuncy.Gather(t.Context(), []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond, time.Minute}, 20*time.Millisecond,
+		shard,
+		gofuncy.WithName("search"),
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithMeterProvider(mp),
+	)
+	require.Len(t, outcomes, 4)
+
+	// the group span ends once the cancelled straggler returned in the background
+	require.Eventually(t, func() bool {
+		_ = tp.ForceFlush(t.Context())
+
+		for _, s := range exp.GetSpans() {
+			if s.Name == "gofuncy.group search" {
+				return true
+			}
+		}
+
+		return false
+	}, time.Second, time.Millisecond)
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.group search")
+
+	v, ok := findAttr(span.Attributes, semconv.GatherCoverageKey)
+	require.True(t, ok)
+	assert.InDelta(t, 0.75, v.AsFloat64(), 0.001)
+
+	v, ok = findAttr(span.Attributes, semconv.GatherTimedOutKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), v.AsInt64())
+}
+
+func TestGather_coverageCountsFailedItems(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	outcomes := gofuncy.Gather(t.Context(), []error{nil, errors.New("boom")}, time.Second,
+		func(ctx context.Context, err error) (int, error) {
+			return 0, err
+		},
+		gofuncy.WithName("search"),
+		gofuncy.WithTracerProvider(tp),
+	)
+	require.Len(t, outcomes, 2)
+	require.EqualError(t, outcomes[1].Err, "boom")
+
+	_ = tp.ForceFlush(t.Context())
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.group search")
+
+	v, ok := findAttr(span.Attributes, semconv.GatherCoverageKey)
+	require.True(t, ok)
+	assert.InDelta(t, 1.0, v.AsFloat64(), 0.001)
+}
+
+// strictLimiter fails acquiring as soon as ctx is done, even if a slot is
+// free, so items queued behind a cancelled context are never admitted.
+type strictLimiter struct {
+	*semaphore.Weighted
+}
+
+func (l strictLimiter) Acquire(ctx context.Context, n int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return l.Weighted.Acquire(ctx, n)
+}
+
+func TestGather_notAdmitted(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	// the first item holds the only slot until ctx is cancelled, so the
+	// others are never admitted and Gather returns before the deadline
+	outcomes := gofuncy.Gather(ctx, []time.Duration{time.Minute, time.Millisecond, time.Millisecond}, time.Minute,
+		shard,
+		gofuncy.WithName("search"),
+		gofuncy.WithLimiter(strictLimiter{semaphore.NewWeighted(1)}),
+		gofuncy.WithTracerProvider(tp),
+	)
+	require.Len(t, outcomes, 3)
+
+	for _, o := range outcomes {
+		require.ErrorIs(t, o.Err, context.Canceled)
+		assert.False(t, o.TimedOut)
+	}
+
+	_ = tp.ForceFlush(t.Context())
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.group search")
+
+	v, ok := findAttr(span.Attributes, semconv.GatherCoverageKey)
+	require.True(t, ok, "coverage must be recorded before the group span ends")
+	assert.InDelta(t, 1.0/3, v.AsFloat64(), 0.001)
+}
+
+func TestGather_keepStragglersDoesNotStartPending(t *testing.T) {
+	t.Parallel()
+
+	delays := []time.Duration{time.Millisecond, 50 * time.Millisecond, time.Millisecond}
+
+	var started [3]atomic.Bool
+
+	finished := make(chan error, 1)
+
+	outcomes := gofuncy.Gather(t.Context(), []int{0, 1, 2}, 20*time.Millisecond,
+		func(ctx context.Context, i int) (time.Duration, error) {
+			started[i].Store(true)
+
+			v, err := shard(ctx, delays[i])
+			if i == 1 {
+				finished <- err
+			}
+
+			return v, err
+		},
+		gofuncy.WithLimit(1),
+		gofuncy.WithKeepStragglers(),
+	)
+	require.Len(t, outcomes, 3)
+	assert.True(t, outcomes[1].TimedOut)
+	assert.True(t, outcomes[2].TimedOut)
+
+	// the straggler completes in the background, the pending item is never
+	// started once its slot frees up
+	require.NoError(t, <-finished)
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, started[2].Load())
+}
@@ -20,16 +20,14 @@ func Go(ctx context.Context, fn Func, opts ...GoOption) {
 	run := withContextInjection(fn, o.name)
 	run = buildChain(run, &o, "gofuncy.go", o.callerSkip+3)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			handleError(ctx, err, o.errorHandler, o.l, o.name)
-			return
-		}
+	if err := admit(ctx, &o); err != nil {
+		handleError(ctx, err, o.errorHandler, o.l, o.name)
+		return
 	}
 
 	go func(ctx context.Context) {
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		ctx, cancel := context.WithCancel(ctx)
@@ -21,20 +21,18 @@ func GoWithCancel(ctx context.Context, fn Func, opts ...GoOption) StopFunc {
 	run := withContextInjection(fn, o.name)
 	run = buildChain(run, &o, "gofuncy.gowithcancel", o.callerSkip+3)
 
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			handleError(ctx, err, o.errorHandler, o.l, o.name)
-			cancel()
+	if err := admit(ctx, &o); err != nil {
+		handleError(ctx, err, o.errorHandler, o.l, o.name)
+		cancel(err)
 
-			return StopFunc(func() {})
-		}
+		return StopFunc(func() {})
 	}
 
 	go func(ctx context.Context) {
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		if ctx.Err() != nil {
@@ -47,5 +45,5 @@ func GoWithCancel(ctx context.Context, fn Func, opts ...GoOption) StopFunc {
 		}
 	}(ctx)
 
-	return StopFunc(cancel)
+	return stopFunc(cancel)
 }
@@ -3,34 +3,73 @@ package gofuncy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/foomo/gofuncy/semconv"
 	"github.com/foomo/gofuncy/semconv/gofuncyconv"
 )
 
+// ErrGroupClosed is reported to the error handler of functions added to a
+// Group after Close.
+var ErrGroupClosed = errors.New("group is closed")
+
 // Group manages a set of concurrently executing functions with shared
 // lifecycle control.
 type Group struct {
-	ctx    context.Context //nolint:containedctx
-	cancel context.CancelFunc
-	o      options
+	parent   context.Context //nolint:containedctx
+	ctx      context.Context //nolint:containedctx
+	cancel   context.CancelCauseFunc
+	o        options
+	detached bool
 
 	wg   sync.WaitGroup
-	sem  chan struct{}
+	sem  *ResizableLimiter
 	once sync.Once
 
-	mu   sync.Mutex
-	errs []error
-	err  error
+	mu       sync.Mutex
+	errs     []error
+	names    []string
+	first    error
+	failures int
+	err      error
+	closed   bool
+	idle     chan struct{}
+	stats    GroupStats
+	limit    int
+	queue    []*groupTask
+	workers  int
+	dequeued chan struct{}
+
+	span   trace.Span
+	start  time.Time
+	reg    metric.Registration
+	waited bool
+	// beforeEnd is called by Wait once all functions completed, before the
+	// group span ends
+	beforeEnd func()
+}
 
-	span  trace.Span
-	start time.Time
+// GroupStats is a snapshot of the functions of a Group.
+type GroupStats struct {
+	// Added is the number of functions added since NewGroup or Reset.
+	Added int64
+	// Active is the number of functions currently running.
+	Active int64
+	// Queued is the number of functions waiting for WithLimit, WithLimiter or
+	// in the WithQueue queue.
+	Queued int64
+	// Failed is the number of functions that failed since NewGroup or Reset.
+	Failed int64
+	// Cancelled is the number of functions cancelled via their Task handle
+	// since NewGroup or Reset. They are not counted as Failed.
+	Cancelled int64
 }
 
 // NewGroup creates a new Group with the given context and options.
@@ -42,43 +81,24 @@ func NewGroup(ctx context.Context, opts ...GroupOption) *Group {
 	}
 
 	g := &Group{
-		ctx: ctx,
-	}
-
-	if o.failFast {
-		g.ctx, g.cancel = context.WithCancel(ctx) //nolint:gosec // cancel is called in Wait()
-	}
-
-	if o.limit > 0 {
-		g.sem = make(chan struct{}, o.limit)
+		parent:   ctx,
+		detached: o.detachedTrace,
+		limit:    o.limit,
 	}
 
-	if o.tracing || o.durationHistogram {
-		g.start = time.Now()
+	// with a queue, the number of workers bounds the concurrency instead;
+	// the limiter is created without a limit too, so SetLimit can add one
+	if o.queueSize == 0 {
+		g.sem = &ResizableLimiter{fifoLimiter: fifoLimiter{limit: int64(o.limit)}}
 	}
 
-	if o.tracing {
-		startOpts := []trace.SpanStartOption{}
-
-		if o.detachedTrace {
-			if parentSpan := trace.SpanFromContext(ctx); parentSpan.SpanContext().IsValid() {
-				startOpts = append(startOpts,
-					trace.WithNewRoot(),
-					trace.WithLinks(trace.Link{SpanContext: parentSpan.SpanContext()}),
-				)
-			}
-		}
-
-		spanName := "gofuncy.group"
-		if o.name != "gofuncy.group" {
-			spanName = "gofuncy.group " + o.name
-		}
-
-		g.ctx, g.span = o.tracer().Start(g.ctx, spanName, startOpts...) //nolint:spancheck
+	// the group span is the parent of the function spans
+	if o.tracing && !o.longLived {
 		o.detachedTrace = false
 	}
 
 	g.o = o
+	g.begin()
 
 	return g
 }
@@ -86,8 +106,31 @@ func NewGroup(ctx context.Context, opts ...GroupOption) *Group {
 // Add spawns a goroutine to execute fn immediately.
 // Per-function opts are merged on top of the group options (additive).
 // User middlewares and panic recovery are applied per fn.
+// With WithLimit or WithLimiter, Add blocks until a slot is free; with
+// WithQueue, fn is queued for the group's workers instead.
 // Use WithName to set a per-task label; defaults to "gofuncy.group.add".
 func (g *Group) Add(fn Func, opts ...GoOption) {
+	g.add(fn, opts, false, nil)
+}
+
+// Go is like Add, but returns a Task handle to cancel fn individually while
+// the rest of the group continues.
+func (g *Group) Go(fn Func, opts ...GoOption) *Task {
+	h := &Task{done: make(chan struct{})}
+	g.add(fn, opts, false, h)
+
+	return h
+}
+
+// TryAdd is like Add, but never blocks: it returns false without adding fn
+// if no slot is free, the WithQueue queue is full or the group is closed.
+// Limiters passed to WithLimiter must implement TryLimiter; others are
+// treated as saturated.
+func (g *Group) TryAdd(fn Func, opts ...GoOption) bool {
+	return g.add(fn, opts, true, nil)
+}
+
+func (g *Group) add(fn Func, opts []GoOption, try bool, h *Task) bool {
 	o := g.o
 	if len(opts) > 0 {
 		o = o.merge(newGoOverrideOptions(opts))
@@ -97,63 +140,209 @@ func (g *Group) Add(fn Func, opts ...GoOption) {
 		o.name = "gofuncy.group.add"
 	}
 
+	// Add, Go and TryAdd call add, so the caller is one frame further up
 	run := withContextInjection(fn, o.name)
-	run = buildChain(run, &o, "gofuncy.group.add", 3)
+	run = buildChain(run, &o, "gofuncy.group.add", 4)
 
-	g.mu.Lock()
-	idx := len(g.errs)
-	g.errs = append(g.errs, nil)
-	g.mu.Unlock()
+	t := &groupTask{ctx: g.ctx, o: o, run: run, handle: h}
+	if h != nil {
+		t.ctx, h.cancel = context.WithCancelCause(g.ctx) //nolint:gosec // cancelled in finish
+	}
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(g.ctx, 1); err != nil {
-			g.mu.Lock()
-			g.errs[idx] = err
-			g.mu.Unlock()
+	if g.o.queueSize > 0 {
+		return g.enqueue(t, try)
+	}
 
-			if g.cancel != nil {
-				g.cancel()
+	if try {
+		if err := checkAdmission(t.ctx, &t.o); err != nil {
+			if g.register(t, false) {
+				g.fail(t, err, true)
+				g.wg.Done()
 			}
 
-			return
+			return false
 		}
-	} else if g.sem != nil {
-		select {
-		case g.sem <- struct{}{}:
-		case <-g.ctx.Done():
-			g.mu.Lock()
-			g.errs[idx] = g.ctx.Err()
-			g.mu.Unlock()
 
-			return
+		if !g.tryAcquire(&t.o) {
+			return false
 		}
+
+		if !g.register(t, false) {
+			g.release(&t.o)
+			return false
+		}
+
+		g.spawn(t, false)
+
+		return true
+	}
+
+	if !g.register(t, true) {
+		return false
+	}
+
+	if err := admit(t.ctx, &t.o); err != nil {
+		g.settle(&g.stats.Queued)
+		g.fail(t, err, true)
+		g.wg.Done()
+
+		return false
 	}
 
-	g.wg.Go(func() {
-		if o.limiter != nil {
-			defer o.limiter.Release(1)
-		} else if g.sem != nil {
-			defer func() { <-g.sem }()
+	if t.o.limiter == nil {
+		if err := g.sem.Acquire(t.ctx, 1); err != nil {
+			g.settle(&g.stats.Queued)
+			g.fail(t, withCause(t.ctx, err), false)
+			g.wg.Done()
+
+			return false
 		}
+	}
 
-		err := run(g.ctx)
+	g.spawn(t, true)
 
-		g.mu.Lock()
-		g.errs[idx] = err
-		g.mu.Unlock()
+	return true
+}
 
-		if err != nil && g.cancel != nil {
-			g.cancel()
+// groupTask is a function added to a Group, with its index in the collected
+// errors (-1 for long-lived groups), its merged options and, if added with
+// Go, its own context and handle.
+type groupTask struct {
+	idx    int
+	ctx    context.Context //nolint:containedctx
+	o      options
+	run    Func
+	handle *Task
+	stop   func() bool
+}
+
+// register assigns the task its error index and counts it as added and, if
+// queued is true, as queued. It returns false if the group is closed; the
+// error is reported to the task's error handler unless queued is false.
+func (g *Group) register(t *groupTask, queued bool) bool {
+	g.mu.Lock()
+	ok := g.registerLocked(t, queued)
+	g.mu.Unlock()
+
+	if !ok && queued {
+		handleError(g.parent, ErrGroupClosed, t.o.errorHandler, t.o.l, t.o.name)
+		t.finish(ErrGroupClosed)
+	}
+
+	return ok
+}
+
+func (g *Group) registerLocked(t *groupTask, queued bool) bool {
+	if g.closed {
+		return false
+	}
+
+	// long-lived groups do not collect errors, so memory stays bounded
+	t.idx = -1
+	if !g.o.longLived {
+		t.idx = len(g.errs)
+		g.errs = append(g.errs, nil)
+		g.names = append(g.names, t.o.name)
+	}
+
+	g.stats.Added++
+
+	if queued {
+		g.stats.Queued++
+	}
+
+	// held until the task completed, so Wait also covers tasks still waiting
+	// for admission when the group is closed
+	g.wg.Add(1)
+
+	return true
+}
+
+// spawn runs an admitted task in a new goroutine, moving it from queued to
+// active if it was counted as queued.
+func (g *Group) spawn(t *groupTask, queued bool) {
+	g.mu.Lock()
+	if queued {
+		g.stats.Queued--
+	}
+
+	g.stats.Active++
+	g.mu.Unlock()
+
+	go func() {
+		defer g.wg.Done()
+		defer g.settle(&g.stats.Active)
+		defer g.release(&t.o)
+
+		if err := t.run(t.ctx); err != nil {
+			g.fail(t, err, true)
+			return
 		}
-	})
+
+		t.finish(nil)
+	}()
+}
+
+// tryAcquire takes a slot from the limiter or WithLimit without blocking.
+func (g *Group) tryAcquire(o *options) bool {
+	if o.limiter != nil {
+		l, ok := o.limiter.(TryLimiter)
+
+		return ok && l.TryAcquire(o.limiterWeight())
+	}
+
+	return g.sem.TryAcquire(1)
+}
+
+// release returns the slot taken from the limiter or WithLimit.
+func (g *Group) release(o *options) {
+	if o.limiter != nil {
+		o.limiter.Release(o.limiterWeight())
+	} else {
+		g.sem.Release(1)
+	}
+}
+
+// SetLimit changes the WithLimit limit of the group at runtime; 0 means no
+// limit. Functions waiting for a slot or in the WithQueue queue are admitted
+// under the new limit, running ones are not interrupted. Functions using
+// WithLimiter are bounded by their limiter instead.
+func (g *Group) SetLimit(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.limit = n
+
+	// groups without a limit have no gauges until one is set
+	if n > 0 && g.reg == nil && !g.waited {
+		g.registerMetrics()
+	}
+
+	if g.sem != nil {
+		g.sem.SetLimit(n)
+		return
+	}
+
+	g.startWorkers(len(g.queue))
 }
 
 // Wait blocks until all added functions complete and returns the joined errors.
 // It is safe to call multiple times — the result is computed once.
+// For a long-lived group, Wait is equivalent to Close and returns nil.
 func (g *Group) Wait() error {
 	g.once.Do(func() {
+		if g.o.longLived {
+			g.mu.Lock()
+			g.closed = true
+			g.mu.Unlock()
+		}
+
 		g.wg.Wait()
 
+		if g.beforeEnd != nil {
+			g.beforeEnd()
+		}
+
 		hasErr := false
 
 		if g.span != nil {
@@ -195,12 +384,304 @@ func (g *Group) Wait() error {
 			groupDuration.Record(context.WithoutCancel(g.ctx), dur, g.o.name, hasErr)
 		}
 
+		g.mu.Lock()
+		reg := g.reg
+		g.reg, g.waited = nil, true
+		g.mu.Unlock()
+
+		if reg != nil {
+			if err := reg.Unregister(); err != nil {
+				otel.Handle(err)
+			}
+		}
+
 		if g.cancel != nil {
-			g.cancel()
+			g.cancel(nil)
 		}
 
-		g.err = errors.Join(g.errs...)
+		g.err = g.joinErrors()
 	})
 
 	return g.err
 }
+
+// WaitIdle blocks until no functions are queued or running, without
+// finishing the group. It may be called concurrently with Add and returns
+// once the group is momentarily idle.
+func (g *Group) WaitIdle() {
+	g.mu.Lock()
+
+	if g.stats.Queued+g.stats.Active == 0 {
+		g.mu.Unlock()
+		return
+	}
+
+	if g.idle == nil {
+		g.idle = make(chan struct{})
+	}
+
+	idle := g.idle
+	g.mu.Unlock()
+
+	<-idle
+}
+
+// Close stops accepting functions and returns the result of Wait. Functions
+// added afterwards are not run; ErrGroupClosed is reported to their error
+// handler instead.
+func (g *Group) Close() error {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+
+	return g.Wait()
+}
+
+// Reset waits for the group like Wait and prepares it for reuse, clearing
+// the collected errors and the Added and Failed stats. It returns the result
+// of Wait. Reset must not be called concurrently with Add.
+func (g *Group) Reset() error {
+	err := g.Wait()
+
+	g.mu.Lock()
+	g.once = sync.Once{}
+	g.errs = nil
+	g.names = nil
+	g.first = nil
+	g.failures = 0
+	g.err = nil
+	g.closed = false
+	g.waited = false
+	g.stats = GroupStats{}
+	g.mu.Unlock()
+
+	g.begin()
+
+	return err
+}
+
+// Stats returns a snapshot of the functions of the group.
+func (g *Group) Stats() GroupStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.stats
+}
+
+// begin starts a run of the group: its context, span and metrics.
+func (g *Group) begin() {
+	g.ctx, g.cancel, g.span, g.reg = g.parent, nil, nil, nil
+
+	if g.o.failFast {
+		g.ctx, g.cancel = context.WithCancelCause(g.parent) //nolint:gosec // cancel is called in Wait()
+	}
+
+	if g.o.tracing || g.o.durationHistogram {
+		g.start = time.Now()
+	}
+
+	if g.o.longLived || g.o.queueSize > 0 || g.limit > 0 {
+		g.registerMetrics()
+	}
+
+	// long-lived groups report periodic metrics instead of a span
+	if g.o.longLived {
+		return
+	}
+
+	if g.o.tracing {
+		startOpts := []trace.SpanStartOption{}
+
+		if g.detached {
+			if parentSpan := trace.SpanFromContext(g.parent); parentSpan.SpanContext().IsValid() {
+				startOpts = append(startOpts,
+					trace.WithNewRoot(),
+					trace.WithLinks(trace.Link{SpanContext: parentSpan.SpanContext()}),
+				)
+			}
+		}
+
+		spanName := "gofuncy.group"
+		if g.o.name != "gofuncy.group" {
+			spanName = "gofuncy.group " + g.o.name
+		}
+
+		g.ctx, g.span = g.o.tracer().Start(g.ctx, spanName, startOpts...) //nolint:spancheck
+	}
+}
+
+// settle decrements the given stats counter and signals WaitIdle once the
+// group is idle.
+func (g *Group) settle(counter *int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	*counter--
+
+	if g.stats.Queued+g.stats.Active == 0 && g.idle != nil {
+		close(g.idle)
+		g.idle = nil
+	}
+}
+
+// fail records the error of the task and cancels the group if the failure
+// policy is exceeded and cancel is true. Tasks of a long-lived group have no
+// index; their errors are passed to the error handler instead. Tasks
+// cancelled via their handle are counted as cancelled and never cancel the
+// group.
+func (g *Group) fail(t *groupTask, err error, cancel bool) {
+	cancelled := t.cancelled()
+	if cancelled && !errors.Is(err, ErrTaskCancelled) {
+		err = fmt.Errorf("%w: %w", err, ErrTaskCancelled)
+	}
+
+	defer t.finish(err)
+
+	if t.idx < 0 && !cancelled {
+		handleError(t.ctx, err, t.o.errorHandler, t.o.l, t.o.name)
+	}
+
+	if cancelled {
+		tasksCancelled, merr := gofuncyconv.NewGroupsTasksCancelled(g.o.meter())
+		if merr != nil {
+			otel.Handle(merr)
+		}
+
+		tasksCancelled.Add(context.WithoutCancel(t.ctx), 1, g.o.name)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if cancelled {
+		g.stats.Cancelled++
+	} else {
+		g.stats.Failed++
+	}
+
+	if t.idx >= 0 {
+		g.errs[t.idx] = err
+
+		if g.first == nil {
+			g.first = err
+		}
+	}
+
+	if cancelled || !cancel || g.cancel == nil {
+		return
+	}
+
+	if g.o.failFastIf != nil && !g.o.failFastIf(err) {
+		return
+	}
+
+	g.failures++
+
+	if g.failures <= g.o.maxFailures {
+		return
+	}
+
+	if g.o.maxFailureRatio > 0 && float64(g.failures)/float64(g.stats.Added) <= g.o.maxFailureRatio {
+		return
+	}
+
+	cause := &ItemError{Index: t.idx, Name: t.o.name, Err: err}
+
+	if g.span != nil && g.ctx.Err() == nil {
+		g.span.SetAttributes(semconv.CancelCause(cause.Error()))
+	}
+
+	g.cancel(cause)
+}
+
+// registerMetrics reports the limit of a group, the active and queued
+// functions of a long-lived group and the queue depth of a group with a
+// queue on every metric collection.
+func (g *Group) registerMetrics() {
+	m := g.o.meter()
+
+	var (
+		insts  []metric.Observable
+		active gofuncyconv.GroupsTasksActive
+		queued gofuncyconv.GroupsTasksQueued
+		depth  gofuncyconv.GroupsQueueDepth
+		err    error
+	)
+
+	limit, err := gofuncyconv.NewGroupsLimit(m)
+	if err != nil {
+		otel.Handle(err)
+		return
+	}
+
+	insts = append(insts, limit.Inst())
+
+	if g.o.longLived {
+		if active, err = gofuncyconv.NewGroupsTasksActive(m); err != nil {
+			otel.Handle(err)
+			return
+		}
+
+		if queued, err = gofuncyconv.NewGroupsTasksQueued(m); err != nil {
+			otel.Handle(err)
+			return
+		}
+
+		insts = append(insts, active.Inst(), queued.Inst())
+	}
+
+	if g.o.queueSize > 0 {
+		if depth, err = gofuncyconv.NewGroupsQueueDepth(m); err != nil {
+			otel.Handle(err)
+			return
+		}
+
+		insts = append(insts, depth.Inst())
+	}
+
+	g.reg, err = m.RegisterCallback(func(_ context.Context, obs metric.Observer) error {
+		g.mu.Lock()
+		stats, queueLen, n := g.stats, len(g.queue), g.limit
+		g.mu.Unlock()
+
+		limit.Observe(obs, int64(max(n, 0)), g.o.name)
+
+		if g.o.longLived {
+			active.Observe(obs, stats.Active, g.o.name)
+			queued.Observe(obs, stats.Queued, g.o.name)
+		}
+
+		if g.o.queueSize > 0 {
+			depth.Observe(obs, int64(queueLen), g.o.name)
+		}
+
+		return nil
+	}, insts...)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// joinErrors returns the errors of the group according to the error mode.
+func (g *Group) joinErrors() error {
+	switch g.o.errorMode {
+	case ErrorModeFirst:
+		return g.first
+	case ErrorModeGroupError:
+		var errs ItemErrors
+
+		for i, err := range g.errs {
+			if err != nil {
+				errs = append(errs, &ItemError{Index: i, Name: g.names[i], Err: err})
+			}
+		}
+
+		if len(errs) == 0 {
+			return nil
+		}
+
+		return &GroupError{Name: g.o.name, Size: len(g.errs), Errors: errs}
+	default:
+		return errors.Join(g.errs...)
+	}
+}
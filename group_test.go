@@ -17,6 +17,8 @@ import (
 	oteltesting "github.com/foomo/opentelemetry-go/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -711,6 +713,51 @@ func TestGroup_withLimiter(t *testing.T) {
 	assert.LessOrEqual(t, maxSeen.Load(), int32(limit))
 }
 
+func TestGroup_withWeight(t *testing.T) {
+	t.Parallel()
+
+	var (
+		active  atomic.Int32
+		maxSeen atomic.Int32
+	)
+
+	sem := semaphore.NewWeighted(10)
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithLimiter(sem),
+		gofuncy.WithWeight(4),
+	)
+
+	for range 6 {
+		g.Add(func(ctx context.Context) error {
+			cur := active.Add(1)
+
+			for {
+				old := maxSeen.Load()
+				if cur <= old || maxSeen.CompareAndSwap(old, cur) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			active.Add(-1)
+
+			return nil
+		})
+	}
+
+	// a per-task weight overrides the group weight
+	g.Add(func(ctx context.Context) error {
+		assert.False(t, sem.TryAcquire(1))
+
+		return nil
+	}, gofuncy.WithWeight(10))
+
+	require.NoError(t, g.Wait())
+	assert.LessOrEqual(t, maxSeen.Load(), int32(2))
+	assert.True(t, sem.TryAcquire(10))
+}
+
 func TestGroup_withLimiterCanceled(t *testing.T) {
 	t.Parallel()
 
@@ -1151,3 +1198,721 @@ func TestGroup_parentCancelPropagates(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorIs(t, err, context.Canceled)
 }
+
+func TestGroup_errorModeFirst(t *testing.T) {
+	t.Parallel()
+
+	errFirst, errLater := errors.New("first"), errors.New("later")
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithErrorMode(gofuncy.ErrorModeFirst))
+
+	g.Add(func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return errLater
+	})
+	g.Add(func(ctx context.Context) error {
+		return errFirst
+	})
+
+	err := g.Wait()
+	require.ErrorIs(t, err, errFirst)
+	require.NotErrorIs(t, err, errLater)
+}
+
+func TestGroup_errorModeGroupError(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithName("sync"),
+		gofuncy.WithErrorMode(gofuncy.ErrorModeGroupError),
+	)
+
+	g.Add(func(ctx context.Context) error { return nil }, gofuncy.WithName("users"))
+	g.Add(func(ctx context.Context) error { return boom }, gofuncy.WithName("orders"))
+	g.Add(func(ctx context.Context) error { return nil })
+
+	err := g.Wait()
+	require.ErrorIs(t, err, boom)
+
+	var groupErr *gofuncy.GroupError
+	require.ErrorAs(t, err, &groupErr)
+	assert.Equal(t, "sync", groupErr.Name)
+	assert.Equal(t, 3, groupErr.Size)
+	require.Len(t, groupErr.Errors, 1)
+	assert.Equal(t, 1, groupErr.Errors[0].Index)
+	assert.Equal(t, "orders", groupErr.Errors[0].Name)
+
+	g = gofuncy.NewGroup(t.Context(), gofuncy.WithErrorMode(gofuncy.ErrorModeGroupError))
+	g.Add(func(ctx context.Context) error { return nil })
+	require.NoError(t, g.Wait())
+}
+
+func TestGroup_failFastIf(t *testing.T) {
+	t.Parallel()
+
+	errNotFound, errFatal := errors.New("not found"), errors.New("fatal")
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithFailFastIf(func(err error) bool { return !errors.Is(err, errNotFound) }),
+	)
+
+	notFound := make(chan struct{})
+
+	g.Add(func(ctx context.Context) error {
+		defer close(notFound)
+		return errNotFound
+	})
+	g.Add(func(ctx context.Context) error {
+		<-notFound
+
+		// an expected error must not cancel siblings
+		time.Sleep(5 * time.Millisecond)
+		assert.NoError(t, ctx.Err())
+
+		return errFatal
+	})
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := g.Wait()
+	require.ErrorIs(t, err, errNotFound)
+	require.ErrorIs(t, err, errFatal)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGroup_failureTolerance(t *testing.T) {
+	t.Parallel()
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithFailureTolerance(1))
+
+	first := make(chan struct{})
+
+	g.Add(func(ctx context.Context) error {
+		defer close(first)
+		return errors.New("tolerated")
+	})
+	g.Add(func(ctx context.Context) error {
+		<-first
+		time.Sleep(5 * time.Millisecond)
+		assert.NoError(t, ctx.Err(), "one failure must be tolerated")
+
+		return errors.New("exceeded")
+	})
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := g.Wait()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGroup_failureRatio(t *testing.T) {
+	t.Parallel()
+
+	run := func(ratio float64) (cancelled bool) {
+		release := make(chan struct{})
+		time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+		g := gofuncy.NewGroup(t.Context(), gofuncy.WithFailureRatio(ratio))
+
+		var canceled atomic.Bool
+
+		for range 2 {
+			g.Add(func(ctx context.Context) error {
+				select {
+				case <-release:
+				case <-ctx.Done():
+					canceled.Store(true)
+				}
+
+				return nil
+			})
+		}
+
+		// 1 of 3 added functions fails
+		g.Add(func(ctx context.Context) error {
+			return errors.New("boom")
+		})
+
+		require.Error(t, g.Wait())
+
+		return canceled.Load()
+	}
+
+	assert.False(t, run(0.5))
+	assert.True(t, run(0.3))
+}
+
+func TestGroup_stats(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimit(1))
+
+	for i := range 3 {
+		go g.Add(func(ctx context.Context) error {
+			<-release
+
+			if i == 0 {
+				return errors.New("boom")
+			}
+
+			return nil
+		})
+	}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 3, Active: 1, Queued: 2}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	close(release)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 3, Failed: 1}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	require.Error(t, g.Wait())
+}
+
+func TestGroup_reset(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithName("batch"),
+		gofuncy.WithFailFast(),
+		gofuncy.WithTracerProvider(tp),
+	)
+
+	g.Add(func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	require.EqualError(t, g.Reset(), "boom")
+	assert.Equal(t, gofuncy.GroupStats{}, g.Stats())
+
+	// the second run is not affected by the cancellation of the first one
+	g.Add(func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	require.NoError(t, g.Wait())
+	assert.Equal(t, gofuncy.GroupStats{Added: 1}, g.Stats())
+
+	tp.ForceFlush(t.Context())
+
+	groupSpans := 0
+
+	for _, s := range exp.GetSpans() {
+		if s.Name == "gofuncy.group batch" {
+			groupSpans++
+		}
+	}
+
+	assert.Equal(t, 2, groupSpans)
+}
+
+func TestGroup_longLived(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	errs := make(chan error, 2)
+	handler := gofuncy.WithErrorHandler(func(ctx context.Context, err error) {
+		errs <- err
+	})
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithName("workers"),
+		gofuncy.WithLongLived(),
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithMeterProvider(mp),
+	)
+
+	var ran atomic.Int32
+
+	for range 10 {
+		g.Add(func(ctx context.Context) error {
+			ran.Add(1)
+			return nil
+		})
+	}
+
+	g.WaitIdle()
+	assert.Equal(t, int32(10), ran.Load())
+
+	g.Add(func(ctx context.Context) error {
+		return errors.New("boom")
+	}, handler)
+	require.EqualError(t, <-errs, "boom")
+
+	g.WaitIdle()
+	assert.Equal(t, gofuncy.GroupStats{Added: 11, Failed: 1}, g.Stats())
+
+	require.NoError(t, g.Close())
+
+	g.Add(func(ctx context.Context) error {
+		ran.Add(1)
+		return nil
+	}, handler)
+	require.ErrorIs(t, <-errs, gofuncy.ErrGroupClosed)
+	assert.Equal(t, int32(10), ran.Load())
+
+	tp.ForceFlush(t.Context())
+
+	for _, s := range exp.GetSpans() {
+		assert.NotEqual(t, "gofuncy.group workers", s.Name, "long-lived groups have no group span")
+	}
+}
+
+func TestGroup_longLived_closeWhileWaitingForSlot(t *testing.T) {
+	t.Parallel()
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLongLived(), gofuncy.WithLimit(1))
+
+	release := make(chan struct{})
+
+	g.Add(func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	var (
+		adding sync.WaitGroup
+		ran    atomic.Bool
+	)
+
+	adding.Go(func() {
+		g.Add(func(ctx context.Context) error {
+			ran.Store(true)
+			return nil
+		})
+	})
+
+	require.Eventually(t, func() bool {
+		return g.Stats().Queued == 1
+	}, time.Second, time.Millisecond)
+
+	closed := make(chan error, 1)
+
+	go func() { closed <- g.Close() }()
+
+	close(release)
+
+	// the function waiting for the slot was added before Close, so Close
+	// must wait for it
+	require.NoError(t, <-closed)
+	assert.True(t, ran.Load())
+
+	adding.Wait()
+}
+
+func TestGroup_TryAdd(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimit(1))
+
+	assert.True(t, g.TryAdd(block))
+	assert.False(t, g.TryAdd(block), "must not block when saturated")
+	assert.Equal(t, gofuncy.GroupStats{Added: 1, Active: 1}, g.Stats())
+
+	close(release)
+	g.WaitIdle()
+
+	assert.True(t, g.TryAdd(block))
+	require.NoError(t, g.Close())
+	assert.False(t, g.TryAdd(block), "must not add to a closed group")
+}
+
+func TestGroup_TryAdd_limiter(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimiter(semaphore.NewWeighted(1)))
+
+	assert.True(t, g.TryAdd(block))
+	assert.False(t, g.TryAdd(block))
+
+	close(release)
+	require.NoError(t, g.Wait())
+}
+
+func TestGroup_queue(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	var (
+		running, peak atomic.Int32
+		ran           atomic.Int32
+	)
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimit(2), gofuncy.WithQueue(10, gofuncy.QueueBlock))
+
+	for range 10 {
+		g.Add(func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			<-release
+			ran.Add(1)
+
+			return nil
+		})
+	}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 10, Active: 2, Queued: 8}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	require.NoError(t, g.Wait())
+	assert.Equal(t, int32(10), ran.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestGroup_queueBlock(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimit(1), gofuncy.WithQueue(1, gofuncy.QueueBlock))
+
+	g.Add(block)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 1, Active: 1}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	g.Add(block)
+	assert.False(t, g.TryAdd(block), "must not block when the queue is full")
+
+	added := make(chan struct{})
+
+	go func() {
+		defer close(added)
+
+		g.Add(block)
+	}()
+
+	select {
+	case <-added:
+		t.Fatal("Add must block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-added
+	require.NoError(t, g.Wait())
+	assert.Equal(t, gofuncy.GroupStats{Added: 3}, g.Stats())
+}
+
+func TestGroup_queueReject(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithLimit(1),
+		gofuncy.WithQueue(1, gofuncy.QueueReject),
+		gofuncy.WithMeterProvider(mp),
+	)
+
+	g.Add(block)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 1, Active: 1}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	g.Add(block)
+	g.Add(block)
+
+	close(release)
+
+	err := g.Wait()
+	require.ErrorIs(t, err, gofuncy.ErrQueueFull)
+	assert.Equal(t, gofuncy.GroupStats{Added: 3, Failed: 1}, g.Stats())
+}
+
+func TestGroup_queueDropOldest(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	var ran []int
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithLimit(1),
+		gofuncy.WithQueue(2, gofuncy.QueueDropOldest),
+		gofuncy.WithErrorMode(gofuncy.ErrorModeGroupError),
+	)
+
+	for i := range 4 {
+		g.Add(func(ctx context.Context) error {
+			<-release
+
+			ran = append(ran, i)
+
+			return nil
+		})
+
+		if i == 0 {
+			assert.EventuallyWithT(t, func(c *assert.CollectT) {
+				assert.Equal(c, int64(1), g.Stats().Active)
+			}, time.Second, time.Millisecond)
+		}
+	}
+
+	close(release)
+
+	var gerr *gofuncy.GroupError
+
+	require.ErrorAs(t, g.Wait(), &gerr)
+	require.Len(t, gerr.Errors, 1)
+	assert.Equal(t, 1, gerr.Errors[0].Index)
+	require.ErrorIs(t, gerr.Errors[0], gofuncy.ErrQueueFull)
+	assert.Equal(t, []int{0, 2, 3}, ran)
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithLimit(1))
+
+	var adding sync.WaitGroup
+
+	for range 3 {
+		adding.Go(func() { g.Add(block) })
+	}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 3, Active: 1, Queued: 2}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	// waiting functions are admitted under the new limit
+	g.SetLimit(3)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 3, Active: 3}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	adding.Wait()
+
+	g.SetLimit(1)
+	assert.False(t, g.TryAdd(block))
+
+	close(release)
+	require.NoError(t, g.Wait())
+}
+
+func TestGroup_SetLimit_metrics(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	// a plain group reports the gofuncy.groups.limit gauge once it has a limit
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithName("resized"), gofuncy.WithMeterProvider(mp))
+	g.SetLimit(2)
+
+	for range 4 {
+		g.Add(func(ctx context.Context) error { return nil })
+	}
+
+	require.NoError(t, g.Wait())
+
+	// the gauge is unregistered by Wait and not registered again
+	g.SetLimit(3)
+}
+
+func TestGroup_SetLimit_queue(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithLimit(1),
+		gofuncy.WithQueue(10, gofuncy.QueueBlock),
+		gofuncy.WithMeterProvider(mp),
+	)
+
+	for range 4 {
+		g.Add(block)
+	}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 4, Active: 1, Queued: 3}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	// queued functions are picked up by additional workers
+	g.SetLimit(3)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 4, Active: 3, Queued: 1}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	require.NoError(t, g.Wait())
+}
+
+func TestGroup_Go(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithFailFast(), gofuncy.WithMeterProvider(mp))
+
+	slow := g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var siblingErr error
+
+	sibling := g.Go(func(ctx context.Context) error {
+		<-slow.Done()
+
+		siblingErr = ctx.Err()
+
+		return nil
+	})
+
+	require.NoError(t, slow.Err(), "must be nil while running")
+
+	slow.Cancel()
+	<-sibling.Done()
+
+	require.NoError(t, siblingErr, "cancelling a task must not cancel the group")
+	require.ErrorIs(t, slow.Err(), gofuncy.ErrTaskCancelled)
+	require.ErrorIs(t, slow.Err(), context.Canceled)
+	require.NoError(t, sibling.Err())
+
+	err := g.Wait()
+	require.ErrorIs(t, err, gofuncy.ErrTaskCancelled)
+	assert.Equal(t, gofuncy.GroupStats{Added: 2, Cancelled: 1}, g.Stats())
+}
+
+func TestGroup_Go_queued(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithLimit(1),
+		gofuncy.WithQueue(10, gofuncy.QueueBlock),
+		gofuncy.WithErrorMode(gofuncy.ErrorModeGroupError),
+	)
+
+	g.Go(func(ctx context.Context) error {
+		<-release
+		return errors.New("boom")
+	})
+
+	var ran atomic.Bool
+
+	obsolete := g.Go(func(ctx context.Context) error {
+		ran.Store(true)
+		return nil
+	})
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, gofuncy.GroupStats{Added: 2, Active: 1, Queued: 1}, g.Stats())
+	}, time.Second, time.Millisecond)
+
+	// a queued task is dropped right away
+	obsolete.Cancel()
+	<-obsolete.Done()
+	require.ErrorIs(t, obsolete.Err(), gofuncy.ErrTaskCancelled)
+
+	close(release)
+
+	var gerr *gofuncy.GroupError
+
+	require.ErrorAs(t, g.Wait(), &gerr)
+	require.Len(t, gerr.Errors, 2)
+	assert.EqualError(t, gerr.Errors[0].Err, "boom")
+	require.ErrorIs(t, gerr.Errors[1], gofuncy.ErrTaskCancelled)
+	assert.False(t, ran.Load())
+	assert.Equal(t, gofuncy.GroupStats{Added: 2, Failed: 1, Cancelled: 1}, g.Stats())
+}
+
+func TestGroup_codeAttributes(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithTracerProvider(tp))
+
+	fn := func(ctx context.Context) error { return nil }
+
+	g.Add(fn)
+	g.Go(fn)
+	require.True(t, g.TryAdd(fn))
+	require.NoError(t, g.Wait())
+
+	tp.ForceFlush(t.Context())
+
+	var n int
+
+	for _, s := range exp.GetSpans() {
+		if s.Name != "gofuncy.group.add" {
+			continue
+		}
+
+		n++
+
+		v, ok := findAttr(s.Attributes, otelsemconv.CodeFilePathKey)
+		require.True(t, ok)
+		assert.Contains(t, v.AsString(), "group_test.go")
+	}
+
+	assert.Equal(t, 3, n)
+}
@@ -0,0 +1,40 @@
+package gofuncy
+
+import (
+	"fmt"
+)
+
+// ErrorMode controls how Group.Wait reports the errors of the group.
+type ErrorMode int
+
+const (
+	// ErrorModeJoin returns all errors via errors.Join, in the order the
+	// functions were added.
+	ErrorModeJoin ErrorMode = iota
+	// ErrorModeFirst returns only the first error that occurred.
+	ErrorModeFirst
+	// ErrorModeGroupError returns a *GroupError listing the name and index of
+	// every failed function.
+	ErrorModeGroupError
+)
+
+// GroupError is returned by Group.Wait with ErrorModeGroupError.
+// errors.Is and errors.As match against each entry.
+type GroupError struct {
+	// Name is the group name.
+	Name string
+	// Size is the number of functions added to the group.
+	Size int
+	// Errors holds an entry per failed function, ordered by index.
+	Errors ItemErrors
+}
+
+// Error implements the error interface for GroupError.
+func (e *GroupError) Error() string {
+	return fmt.Sprintf("%s: %d of %d failed\n%s", e.Name, len(e.Errors), e.Size, e.Errors.Error())
+}
+
+// Unwrap returns the item errors.
+func (e *GroupError) Unwrap() []error {
+	return e.Errors.Unwrap()
+}
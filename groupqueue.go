@@ -0,0 +1,234 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// ErrQueueFull is the error of functions rejected or dropped because the
+// WithQueue queue of a Group was full.
+var ErrQueueFull = errors.New("group queue is full")
+
+// QueuePolicy controls what Group.Add does when the WithQueue queue is full.
+type QueuePolicy int
+
+const (
+	// QueueBlock blocks Add until the queue has room or the group context is
+	// done.
+	QueueBlock QueuePolicy = iota
+	// QueueReject fails the added function with ErrQueueFull.
+	QueueReject
+	// QueueDropOldest fails the oldest queued function with ErrQueueFull to
+	// make room for the added one.
+	QueueDropOldest
+)
+
+// enqueue adds the task to the queue, applying the queue policy if it is
+// full, and starts a worker if fewer than WithLimit are running.
+func (g *Group) enqueue(t *groupTask, try bool) bool {
+	g.mu.Lock()
+
+	if try && (g.closed || len(g.queue) >= g.o.queueSize) {
+		g.mu.Unlock()
+		return false
+	}
+
+	if !g.registerLocked(t, true) {
+		g.mu.Unlock()
+		handleError(g.parent, ErrGroupClosed, t.o.errorHandler, t.o.l, t.o.name)
+		t.finish(ErrGroupClosed)
+
+		return false
+	}
+
+	for len(g.queue) >= g.o.queueSize {
+		switch g.o.queuePolicy {
+		case QueueReject:
+			g.mu.Unlock()
+			g.reject(t, ErrQueueFull)
+
+			return false
+		case QueueDropOldest:
+			oldest := g.dequeueLocked(0)
+
+			g.mu.Unlock()
+			g.reject(oldest, ErrQueueFull)
+			g.mu.Lock()
+		default:
+			if g.dequeued == nil {
+				g.dequeued = make(chan struct{})
+			}
+
+			dequeued := g.dequeued
+			g.mu.Unlock()
+
+			select {
+			case <-dequeued:
+			case <-t.ctx.Done():
+				g.reject(t, withCause(t.ctx, t.ctx.Err()))
+
+				return false
+			}
+
+			g.mu.Lock()
+
+			if g.closed {
+				g.mu.Unlock()
+				g.reject(t, ErrGroupClosed)
+
+				return false
+			}
+		}
+	}
+
+	g.queue = append(g.queue, t)
+	g.startWorkers(1)
+
+	// drop the task from the queue as soon as it is cancelled via its handle
+	if t.handle != nil {
+		t.stop = context.AfterFunc(t.ctx, func() { g.unqueue(t) })
+	}
+
+	g.mu.Unlock()
+
+	return true
+}
+
+// startWorkers starts up to n workers while fewer than the limit are
+// running. Must be called while g.mu is held.
+func (g *Group) startWorkers(n int) {
+	for range n {
+		if g.limit > 0 && g.workers >= g.limit {
+			return
+		}
+
+		g.workers++
+		g.wg.Go(g.work)
+	}
+}
+
+// work runs queued tasks until the queue is empty or the limit was lowered
+// below the number of workers.
+func (g *Group) work() {
+	for {
+		g.mu.Lock()
+
+		if len(g.queue) == 0 || (g.limit > 0 && g.workers > g.limit) {
+			g.workers--
+			g.mu.Unlock()
+
+			return
+		}
+
+		t := g.dequeueLocked(0)
+		g.mu.Unlock()
+
+		g.runQueued(t)
+	}
+}
+
+// dequeueLocked removes the task at index i from the queue and wakes a
+// producer blocked on a full queue. Must be called while g.mu is held.
+func (g *Group) dequeueLocked(i int) *groupTask {
+	t := g.queue[i]
+
+	if i == 0 {
+		g.queue[0] = nil
+		g.queue = g.queue[1:]
+	} else {
+		last := len(g.queue) - 1
+		copy(g.queue[i:], g.queue[i+1:])
+		g.queue[last] = nil
+		g.queue = g.queue[:last]
+	}
+
+	if t.stop != nil {
+		t.stop()
+	}
+
+	if g.dequeued != nil {
+		close(g.dequeued)
+		g.dequeued = nil
+	}
+
+	return t
+}
+
+// unqueue fails a task whose context was cancelled while it was queued.
+// Tasks already taken by a worker are failed by the worker instead.
+func (g *Group) unqueue(t *groupTask) {
+	g.mu.Lock()
+
+	for i, queued := range g.queue {
+		if queued != t {
+			continue
+		}
+
+		g.dequeueLocked(i)
+		g.mu.Unlock()
+
+		g.reject(t, withCause(t.ctx, t.ctx.Err()))
+
+		return
+	}
+
+	g.mu.Unlock()
+}
+
+// runQueued runs a task taken from the queue on the calling worker.
+func (g *Group) runQueued(t *groupTask) {
+	defer g.wg.Done()
+
+	if err := t.ctx.Err(); err != nil {
+		g.settle(&g.stats.Queued)
+		g.fail(t, withCause(t.ctx, err), false)
+
+		return
+	}
+
+	if err := admit(t.ctx, &t.o); err != nil {
+		g.settle(&g.stats.Queued)
+		g.fail(t, err, true)
+
+		return
+	}
+
+	if t.o.limiter != nil {
+		defer t.o.limiter.Release(t.o.limiterWeight())
+	}
+
+	g.mu.Lock()
+	g.stats.Queued--
+	g.stats.Active++
+	g.mu.Unlock()
+
+	defer g.settle(&g.stats.Active)
+
+	if err := t.run(t.ctx); err != nil {
+		g.fail(t, err, true)
+		return
+	}
+
+	t.finish(nil)
+}
+
+// reject fails a queued task that will not run.
+func (g *Group) reject(t *groupTask, err error) {
+	defer g.wg.Done()
+
+	g.settle(&g.stats.Queued)
+	g.fail(t, err, false)
+
+	if errors.Is(err, ErrQueueFull) {
+		rejected, merr := gofuncyconv.NewGroupsQueueRejected(g.o.meter())
+		if merr != nil {
+			otel.Handle(merr)
+		}
+
+		rejected.Add(context.WithoutCancel(g.ctx), 1, g.o.name)
+	}
+}
@@ -0,0 +1,194 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/foomo/gofuncy/semconv"
+)
+
+// ErrExecutorClosed is returned when submitting to a closed KeyedExecutor.
+var ErrExecutorClosed = errors.New("executor is closed")
+
+// KeyedExecutor runs submitted functions sequentially per key and in parallel
+// across keys, e.g. event handlers that must process each aggregate in order.
+// A key's worker goroutine exists only while the key has pending functions,
+// so idle keys hold no resources.
+type KeyedExecutor struct {
+	ctx context.Context //nolint:containedctx
+	o   options
+	sem chan struct{}
+
+	mu      sync.Mutex
+	keys    map[string][]func()
+	pending int
+	idle    chan struct{}
+	closed  bool
+}
+
+// NewKeyedExecutor creates a new KeyedExecutor. All functions run with ctx.
+// WithLimit bounds the number of keys executing concurrently; other options
+// apply to every submitted function, like group options apply to Group.Add.
+// Use WithName to set a custom metric/tracing label; defaults to "gofuncy.keyed".
+func NewKeyedExecutor(ctx context.Context, opts ...GroupOption) *KeyedExecutor {
+	o := newGroupOptions(opts)
+	if o.name == "" {
+		o.name = "gofuncy.keyed"
+	}
+
+	e := &KeyedExecutor{
+		ctx:  ctx,
+		o:    o,
+		keys: map[string][]func(){},
+	}
+
+	if o.limit > 0 {
+		e.sem = make(chan struct{}, o.limit)
+	}
+
+	return e
+}
+
+// Submit queues fn for execution after all previously submitted functions
+// with the same key. It does not block. Errors are handled like in Go: logged
+// via slog by default, or passed to WithErrorHandler. Per-function opts are
+// merged on top of the executor options.
+func (e *KeyedExecutor) Submit(key string, fn Func, opts ...GoOption) error {
+	o := e.o
+	if len(opts) > 0 {
+		o = o.merge(newGoOverrideOptions(opts))
+	}
+
+	run := withContextInjection(withExecutorKey(fn, key), o.name)
+	run = buildChain(run, &o, "gofuncy.keyed", 3)
+
+	task := func() {
+		ctx := e.ctx
+		if ctx.Err() != nil {
+			handleError(ctx, ctx.Err(), o.errorHandler, o.l, o.name)
+			return
+		}
+
+		if err := admit(ctx, &o); err != nil {
+			handleError(ctx, err, o.errorHandler, o.l, o.name)
+			return
+		}
+
+		if o.limiter != nil {
+			defer o.limiter.Release(o.limiterWeight())
+		}
+
+		if err := run(ctx); err != nil {
+			handleError(ctx, err, o.errorHandler, o.l, o.name)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return ErrExecutorClosed
+	}
+
+	e.pending++
+
+	queue, running := e.keys[key]
+	e.keys[key] = append(queue, task)
+
+	if !running {
+		go e.work(key)
+	}
+
+	return nil
+}
+
+// Wait blocks until all submitted functions have completed. It may be called
+// concurrently with Submit and returns once the executor is momentarily idle.
+func (e *KeyedExecutor) Wait() {
+	e.mu.Lock()
+
+	if e.pending == 0 {
+		e.mu.Unlock()
+		return
+	}
+
+	if e.idle == nil {
+		e.idle = make(chan struct{})
+	}
+
+	idle := e.idle
+	e.mu.Unlock()
+
+	<-idle
+}
+
+// Close stops accepting new functions and waits for all pending ones.
+func (e *KeyedExecutor) Close() {
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+
+	e.Wait()
+}
+
+// Keys returns the number of keys with pending functions.
+func (e *KeyedExecutor) Keys() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return len(e.keys)
+}
+
+// work runs the functions queued for key in order and removes the key once
+// its queue is drained.
+func (e *KeyedExecutor) work(key string) {
+	if e.sem != nil {
+		select {
+		case e.sem <- struct{}{}:
+			defer func() { <-e.sem }()
+		case <-e.ctx.Done():
+			// drain without a slot; the queued functions fail fast
+		}
+	}
+
+	for {
+		e.mu.Lock()
+
+		queue := e.keys[key]
+		if len(queue) == 0 {
+			delete(e.keys, key)
+			e.mu.Unlock()
+
+			return
+		}
+
+		task := queue[0]
+		queue[0] = nil
+		e.keys[key] = queue[1:]
+		e.mu.Unlock()
+
+		task()
+
+		e.mu.Lock()
+
+		e.pending--
+		if e.pending == 0 && e.idle != nil {
+			close(e.idle)
+			e.idle = nil
+		}
+
+		e.mu.Unlock()
+	}
+}
+
+// withExecutorKey records the key on the span of the invocation.
+func withExecutorKey(fn Func, key string) Func {
+	return func(ctx context.Context) error {
+		trace.SpanFromContext(ctx).SetAttributes(semconv.ExecutorKey(key))
+
+		return fn(ctx)
+	}
+}
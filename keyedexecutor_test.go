@@ -0,0 +1,167 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/semconv"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestKeyedExecutor_orderPerKey(t *testing.T) {
+	t.Parallel()
+
+	e := gofuncy.NewKeyedExecutor(t.Context())
+
+	var (
+		mu   sync.Mutex
+		seen = map[string][]int{}
+	)
+
+	for i := range 50 {
+		for _, key := range []string{"a", "b", "c"} {
+			require.NoError(t, e.Submit(key, func(ctx context.Context) error {
+				mu.Lock()
+				seen[key] = append(seen[key], i)
+				mu.Unlock()
+
+				return nil
+			}))
+		}
+	}
+
+	e.Wait()
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.Len(t, seen[key], 50)
+
+		for i, v := range seen[key] {
+			assert.Equal(t, i, v)
+		}
+	}
+
+	assert.Zero(t, e.Keys())
+}
+
+func TestKeyedExecutor_boundedKeys(t *testing.T) {
+	t.Parallel()
+
+	e := gofuncy.NewKeyedExecutor(t.Context(), gofuncy.WithLimit(2))
+
+	var (
+		active, peak atomic.Int32
+		perKey       sync.Map
+	)
+
+	for i := range 20 {
+		key := fmt.Sprintf("key-%d", i%5)
+
+		require.NoError(t, e.Submit(key, func(ctx context.Context) error {
+			counter, _ := perKey.LoadOrStore(key, &atomic.Int32{})
+			if counter.(*atomic.Int32).Add(1) > 1 {
+				t.Errorf("concurrent execution for %s", key)
+			}
+
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(2 * time.Millisecond)
+			active.Add(-1)
+			counter.(*atomic.Int32).Add(-1)
+
+			return nil
+		}))
+	}
+
+	e.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Zero(t, e.Keys())
+}
+
+func TestKeyedExecutor_taskOptions(t *testing.T) {
+	t.Parallel()
+
+	e := gofuncy.NewKeyedExecutor(t.Context(), gofuncy.WithRetry(3))
+
+	var (
+		calls atomic.Int32
+		errs  = make(chan error, 1)
+	)
+
+	require.NoError(t, e.Submit("a", func(ctx context.Context) error {
+		if calls.Add(1) < 3 {
+			return errors.New("transient")
+		}
+
+		return nil
+	}))
+
+	require.NoError(t, e.Submit("a", func(ctx context.Context) error {
+		return errors.New("boom")
+	}, gofuncy.WithErrorHandler(func(ctx context.Context, err error) {
+		errs <- err
+	}), gofuncy.WithRetry(1)))
+
+	e.Wait()
+
+	assert.Equal(t, int32(3), calls.Load())
+	require.EqualError(t, <-errs, "boom")
+}
+
+func TestKeyedExecutor_close(t *testing.T) {
+	t.Parallel()
+
+	e := gofuncy.NewKeyedExecutor(t.Context())
+
+	var done atomic.Bool
+
+	require.NoError(t, e.Submit("a", func(ctx context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		done.Store(true)
+
+		return nil
+	}))
+
+	e.Close()
+
+	assert.True(t, done.Load())
+	require.ErrorIs(t, e.Submit("a", func(ctx context.Context) error { return nil }), gofuncy.ErrExecutorClosed)
+}
+
+func TestKeyedExecutor_keyAttribute(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	e := gofuncy.NewKeyedExecutor(t.Context(),
+		gofuncy.WithName("orders"),
+		gofuncy.WithTracerProvider(tp),
+	)
+
+	require.NoError(t, e.Submit("order-1", func(ctx context.Context) error { return nil }))
+	e.Wait()
+
+	tp.ForceFlush(t.Context())
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.keyed orders")
+
+	key, ok := findAttr(span.Attributes, semconv.ExecutorKeyKey)
+	require.True(t, ok)
+	assert.Equal(t, "order-1", key.AsString())
+}
@@ -0,0 +1,173 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/foomo/gofuncy/semconv"
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// ErrLimiterTimeout is returned when the limiter could not be acquired within
+// the WithLimiterTimeout duration.
+var ErrLimiterTimeout = errors.New("limiter acquire timeout")
+
+// limiterWaitEventThreshold is the minimum wait recorded as a span event, so
+// uncontended acquisitions do not flood the parent span.
+const limiterWaitEventThreshold = time.Millisecond
+
+// Limiter bounds the number of concurrently executing functions across call
+// sites. *semaphore.Weighted satisfies this interface.
+type Limiter interface {
+	Acquire(ctx context.Context, n int64) error
+	Release(n int64)
+}
+
+// TryLimiter is implemented by limiters that can be acquired without
+// blocking, as required by Group.TryAdd. *semaphore.Weighted, AdaptiveLimiter,
+// FairLimiter and ResizableLimiter satisfy this interface.
+type TryLimiter interface {
+	TryAcquire(n int64) bool
+}
+
+// LimiterObserver is implemented by limiters that adapt to observed outcomes,
+// such as AdaptiveLimiter. When the limiter passed to WithLimiter implements
+// it, every invocation reports its latency and error after completion.
+type LimiterObserver interface {
+	Observe(latency time.Duration, err error)
+}
+
+// limiterAttributer is implemented by limiters that add attributes, such as
+// their name or the caller's tenant, to the limiter wait histogram.
+type limiterAttributer interface {
+	waitAttributes(ctx context.Context) []attribute.KeyValue
+}
+
+// limiterWaitHistograms caches the limiter wait histogram per meter, so it is
+// not created on every acquisition.
+var limiterWaitHistograms sync.Map
+
+func limiterWaitHistogram(m metric.Meter) gofuncyconv.LimiterWaitDuration {
+	return cachedInstrument(&limiterWaitHistograms, m, gofuncyconv.NewLimiterWaitDuration)
+}
+
+// cachedInstrument returns the instrument for m from cache, creating it with
+// newFn on first use.
+func cachedInstrument[T any](cache *sync.Map, m metric.Meter, newFn func(metric.Meter) (T, error)) T {
+	if v, ok := cache.Load(m); ok {
+		return v.(T) //nolint:forcetypeassert
+	}
+
+	v, err := newFn(m)
+	if err != nil {
+		otel.Handle(err)
+		return v
+	}
+
+	cache.Store(m, v)
+
+	return v
+}
+
+// admit rejects the invocation if an admission check of o fails and then
+// acquires o.limiter, if any. The checks run first, so rejected invocations
+// never wait for a limiter slot.
+func admit(ctx context.Context, o *options) error {
+	if err := checkAdmission(ctx, o); err != nil {
+		return err
+	}
+
+	if o.limiter == nil {
+		return nil
+	}
+
+	return acquireLimiter(ctx, o)
+}
+
+// checkAdmission returns ErrOverloaded while the WithLoadShedder shedder is
+// overloaded and a *DeadlineAdmissionError if the remaining deadline is too
+// short for WithDeadlineAdmission.
+func checkAdmission(ctx context.Context, o *options) error {
+	if o.loadShedder != nil {
+		if err := o.loadShedder.shed(ctx, o.meter(), o.name); err != nil {
+			return err
+		}
+	}
+
+	if o.admission > 0 {
+		return checkDeadline(ctx, o.admission, o.scope)
+	}
+
+	return nil
+}
+
+// acquireLimiter acquires the configured weight from o.limiter, giving up with
+// ErrLimiterTimeout after o.limiterTimeout. The wait is recorded in the limiter
+// wait histogram, reported to the WithLoadShedder shedder and, when tracing is
+// enabled, added as an event on the caller's span.
+func acquireLimiter(ctx context.Context, o *options) error {
+	start := time.Now()
+
+	actx := ctx
+
+	if o.limiterTimeout > 0 {
+		var cancel context.CancelFunc
+
+		actx, cancel = context.WithTimeout(ctx, o.limiterTimeout)
+		defer cancel()
+	}
+
+	err := o.limiter.Acquire(actx, o.limiterWeight())
+	if err != nil && actx.Err() != nil && ctx.Err() == nil {
+		err = ErrLimiterTimeout
+	}
+
+	wait := time.Since(start)
+
+	if err == nil && o.loadShedder != nil {
+		o.loadShedder.Observe(wait)
+	}
+
+	var attrs []attribute.KeyValue
+	if a, ok := o.limiter.(limiterAttributer); ok {
+		attrs = a.waitAttributes(ctx)
+	}
+
+	limiterWaitHistogram(o.meter()).Record(ctx, wait.Seconds(), o.name, err != nil, attrs...)
+
+	if o.tracing {
+		span := trace.SpanFromContext(ctx)
+
+		switch {
+		case errors.Is(err, ErrLimiterTimeout):
+			span.AddEvent(semconv.EventLimiterTimeout, trace.WithAttributes(
+				semconv.RoutineName(o.name),
+				semconv.LimiterWait(wait),
+			))
+		case wait >= limiterWaitEventThreshold:
+			span.AddEvent(semconv.EventLimiterWait, trace.WithAttributes(
+				semconv.RoutineName(o.name),
+				semconv.LimiterWait(wait),
+			))
+		}
+	}
+
+	return err
+}
+
+func withLimiterFeedback(fn Func, obs LimiterObserver) Func {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		err := fn(ctx)
+		obs.Observe(time.Since(start), err)
+
+		return err
+	}
+}
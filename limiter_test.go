@@ -0,0 +1,126 @@
+package gofuncy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/semconv"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestWithLimiter_typedNil(t *testing.T) {
+	t.Parallel()
+
+	var sem *semaphore.Weighted
+
+	// a nil semaphore means no limiter, as before WithLimiter took a Limiter
+	require.NoError(t, gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		return nil
+	}, gofuncy.WithLimiter(sem)))
+}
+
+func TestWithLimiterTimeout(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	sem := semaphore.NewWeighted(1)
+	require.True(t, sem.TryAcquire(1))
+
+	called := false
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		called = true
+		return nil
+	},
+		gofuncy.WithLimiter(sem),
+		gofuncy.WithLimiterTimeout(10*time.Millisecond),
+		gofuncy.WithMeterProvider(mp),
+	)
+	require.ErrorIs(t, err, gofuncy.ErrLimiterTimeout)
+	assert.False(t, called)
+
+	sem.Release(1)
+	assert.True(t, sem.TryAcquire(1))
+}
+
+func TestWithLimiterTimeout_parentCancellation(t *testing.T) {
+	t.Parallel()
+
+	sem := semaphore.NewWeighted(1)
+	require.True(t, sem.TryAcquire(1))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		return nil
+	},
+		gofuncy.WithLimiter(sem),
+		gofuncy.WithLimiterTimeout(time.Minute),
+	)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, gofuncy.ErrLimiterTimeout)
+}
+
+func TestWithLimiterTimeout_group(t *testing.T) {
+	t.Parallel()
+
+	sem := semaphore.NewWeighted(1)
+	require.True(t, sem.TryAcquire(1))
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithLimiter(sem),
+		gofuncy.WithLimiterTimeout(10*time.Millisecond),
+	)
+
+	g.Add(func(ctx context.Context) error {
+		return nil
+	})
+
+	require.ErrorIs(t, g.Wait(), gofuncy.ErrLimiterTimeout)
+}
+
+func TestWithLimiter_waitEvent(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	sem := semaphore.NewWeighted(1)
+	require.True(t, sem.TryAcquire(1))
+
+	time.AfterFunc(20*time.Millisecond, func() { sem.Release(1) })
+
+	g := gofuncy.NewGroup(t.Context(),
+		gofuncy.WithName("batch"),
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithLimiter(sem),
+	)
+
+	g.Add(func(ctx context.Context) error {
+		return nil
+	}, gofuncy.WithName("item"))
+
+	require.NoError(t, g.Wait())
+
+	tp.ForceFlush(t.Context())
+
+	span := findSpan(t, exp.GetSpans(), "gofuncy.group batch")
+	event := findEvent(t, span.Events, semconv.EventLimiterWait)
+
+	name, ok := findAttr(event.Attributes, semconv.RoutineNameKey)
+	require.True(t, ok)
+	assert.Equal(t, "item", name.AsString())
+
+	wait, ok := findAttr(event.Attributes, semconv.LimiterWaitKey)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, wait.AsFloat64(), 0.015)
+}
@@ -0,0 +1,139 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// ErrOverloaded is returned when a LoadShedder sheds an invocation.
+var ErrOverloaded = errors.New("overloaded")
+
+// LoadShedderOption configures load shedder behavior.
+type LoadShedderOption func(*loadShedderConfig)
+
+type loadShedderConfig struct {
+	target   time.Duration
+	interval time.Duration
+	exempt   func(ctx context.Context) bool
+}
+
+// LoadShedder sheds invocations while admitted work is queueing for too long,
+// following the CoDel (controlled delay) approach: if the minimum queueing
+// delay observed over an interval exceeds the target, the shedder considers
+// itself overloaded for the next interval and rejects new invocations with
+// ErrOverloaded. Using the minimum ignores short bursts and only reacts to a
+// standing queue.
+//
+// Queueing delay is observed automatically from the WithLimiter wait of every
+// invocation using the shedder; other delays, e.g. channel latency, can be
+// reported via Observe. It is safe for concurrent use and should be shared
+// across all calls to the same dependency.
+type LoadShedder struct {
+	mu            sync.Mutex
+	intervalStart time.Time
+	minDelay      time.Duration
+	sampled       bool
+	overloaded    bool
+	cfg           loadShedderConfig
+}
+
+// NewLoadShedder creates a new LoadShedder with the given options.
+func NewLoadShedder(opts ...LoadShedderOption) *LoadShedder {
+	s := &LoadShedder{
+		intervalStart: time.Now(),
+		cfg: loadShedderConfig{
+			target:   5 * time.Millisecond,
+			interval: 100 * time.Millisecond,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&s.cfg)
+	}
+
+	return s
+}
+
+// Observe reports the queueing delay of an admitted invocation.
+func (s *LoadShedder) Observe(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.roll(time.Now())
+
+	if !s.sampled || delay < s.minDelay {
+		s.minDelay = delay
+		s.sampled = true
+	}
+}
+
+// Overloaded reports whether the shedder is currently shedding invocations.
+func (s *LoadShedder) Overloaded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.roll(time.Now())
+
+	return s.overloaded
+}
+
+// roll closes the current interval once it has elapsed and re-evaluates the
+// overload state from its minimum delay. If a whole interval passed without
+// samples, nothing is queueing and the shedder recovers.
+// Must be called while s.mu is held.
+func (s *LoadShedder) roll(now time.Time) {
+	elapsed := now.Sub(s.intervalStart)
+	if elapsed < s.cfg.interval {
+		return
+	}
+
+	s.overloaded = s.sampled && s.minDelay > s.cfg.target && elapsed < 2*s.cfg.interval
+	s.intervalStart = now
+	s.sampled = false
+	s.minDelay = 0
+}
+
+// goroutinesShedCounters caches the shed counter per meter.
+var goroutinesShedCounters sync.Map
+
+// shed returns ErrOverloaded and counts the invocation as shed while the
+// shedder is overloaded, unless ctx is exempt.
+func (s *LoadShedder) shed(ctx context.Context, m metric.Meter, name string) error {
+	if !s.Overloaded() || (s.cfg.exempt != nil && s.cfg.exempt(ctx)) {
+		return nil
+	}
+
+	cachedInstrument(&goroutinesShedCounters, m, gofuncyconv.NewGoroutinesShed).Add(ctx, 1, name)
+
+	return ErrOverloaded
+}
+
+// LoadShedderTarget sets the acceptable standing queueing delay. Defaults to 5ms.
+func LoadShedderTarget(d time.Duration) LoadShedderOption {
+	return func(c *loadShedderConfig) {
+		c.target = d
+	}
+}
+
+// LoadShedderInterval sets the window over which the minimum queueing delay
+// is tracked. It should be on the order of the worst-case latency of the
+// protected operation. Defaults to 100ms.
+func LoadShedderInterval(d time.Duration) LoadShedderOption {
+	return func(c *loadShedderConfig) {
+		c.interval = d
+	}
+}
+
+// LoadShedderExempt sets a predicate for invocations that must never be shed,
+// e.g. high-priority or health-check work.
+func LoadShedderExempt(fn func(ctx context.Context) bool) LoadShedderOption {
+	return func(c *loadShedderConfig) {
+		c.exempt = fn
+	}
+}
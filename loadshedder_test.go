@@ -0,0 +1,146 @@
+package gofuncy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestLoadShedder_shedsOnStandingQueue(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	s := gofuncy.NewLoadShedder(
+		gofuncy.LoadShedderTarget(time.Millisecond),
+		gofuncy.LoadShedderInterval(20*time.Millisecond),
+	)
+
+	s.Observe(5 * time.Millisecond)
+	s.Observe(3 * time.Millisecond)
+	assert.False(t, s.Overloaded())
+
+	time.Sleep(25 * time.Millisecond)
+
+	called := false
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		called = true
+		return nil
+	}, gofuncy.WithLoadShedder(s), gofuncy.WithMeterProvider(mp))
+
+	require.ErrorIs(t, err, gofuncy.ErrOverloaded)
+	assert.False(t, called)
+}
+
+func TestLoadShedder_ignoresBursts(t *testing.T) {
+	t.Parallel()
+
+	s := gofuncy.NewLoadShedder(
+		gofuncy.LoadShedderTarget(time.Millisecond),
+		gofuncy.LoadShedderInterval(20*time.Millisecond),
+	)
+
+	s.Observe(50 * time.Millisecond)
+	s.Observe(0) // the queue drained within the interval
+
+	time.Sleep(25 * time.Millisecond)
+
+	assert.False(t, s.Overloaded())
+}
+
+func TestLoadShedder_recovers(t *testing.T) {
+	t.Parallel()
+
+	s := gofuncy.NewLoadShedder(
+		gofuncy.LoadShedderTarget(time.Millisecond),
+		gofuncy.LoadShedderInterval(20*time.Millisecond),
+	)
+
+	s.Observe(5 * time.Millisecond)
+	time.Sleep(25 * time.Millisecond)
+	require.True(t, s.Overloaded())
+
+	// an interval without queueing delay ends the overload
+	time.Sleep(25 * time.Millisecond)
+	assert.False(t, s.Overloaded())
+}
+
+func TestLoadShedder_exempt(t *testing.T) {
+	t.Parallel()
+
+	s := gofuncy.NewLoadShedder(
+		gofuncy.LoadShedderTarget(time.Millisecond),
+		gofuncy.LoadShedderInterval(20*time.Millisecond),
+		gofuncy.LoadShedderExempt(func(ctx context.Context) bool {
+			return gofuncy.PriorityFromContext(ctx) > 0
+		}),
+	)
+
+	s.Observe(5 * time.Millisecond)
+	time.Sleep(25 * time.Millisecond)
+
+	fn := func(ctx context.Context) error { return nil }
+
+	require.ErrorIs(t, gofuncy.Do(t.Context(), fn, gofuncy.WithLoadShedder(s)), gofuncy.ErrOverloaded)
+	require.NoError(t, gofuncy.Do(gofuncy.ContextWithPriority(t.Context(), 1), fn, gofuncy.WithLoadShedder(s)))
+}
+
+func TestLoadShedder_observesLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	s := gofuncy.NewLoadShedder(
+		gofuncy.LoadShedderTarget(time.Millisecond),
+		gofuncy.LoadShedderInterval(50*time.Millisecond),
+	)
+
+	sem := semaphore.NewWeighted(1)
+	require.True(t, sem.TryAcquire(1))
+
+	time.AfterFunc(10*time.Millisecond, func() { sem.Release(1) })
+
+	fn := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, gofuncy.Do(t.Context(), fn, gofuncy.WithLimiter(sem), gofuncy.WithLoadShedder(s)))
+
+	time.Sleep(50 * time.Millisecond)
+
+	require.ErrorIs(t, gofuncy.Do(t.Context(), fn, gofuncy.WithLimiter(sem), gofuncy.WithLoadShedder(s)), gofuncy.ErrOverloaded)
+}
+
+func TestLoadShedder_shedsBeforeLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	s := gofuncy.NewLoadShedder(
+		gofuncy.LoadShedderTarget(time.Millisecond),
+		gofuncy.LoadShedderInterval(50*time.Millisecond),
+	)
+
+	sem := semaphore.NewWeighted(1)
+	require.True(t, sem.TryAcquire(1))
+
+	time.AfterFunc(10*time.Millisecond, func() { sem.Release(1) })
+
+	fn := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, gofuncy.Do(t.Context(), fn, gofuncy.WithLimiter(sem), gofuncy.WithLoadShedder(s)))
+
+	time.Sleep(50 * time.Millisecond)
+
+	// the limiter is saturated: a shed invocation must not wait for it
+	require.True(t, sem.TryAcquire(1))
+	defer sem.Release(1)
+
+	start := time.Now()
+	err := gofuncy.Do(t.Context(), fn, gofuncy.WithLimiter(sem), gofuncy.WithLoadShedder(s), gofuncy.WithLimiterTimeout(time.Second))
+
+	require.ErrorIs(t, err, gofuncy.ErrOverloaded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
@@ -9,6 +9,17 @@ import (
 // All GroupOption options apply (WithLimit, WithFailFast, telemetry, etc.).
 // Use WithName to set a custom metric/tracing label; defaults to "gofuncy.map".
 func Map[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error), opts ...GroupOption) ([]R, error) {
+	return mapItems(ctx, items, nil, fn, opts)
+}
+
+// MapWeighted is like Map, but acquires weightFn(item) from the WithLimiter
+// limiter for each item instead of 1, so the limiter bounds e.g. the memory
+// or connections used by the items in flight.
+func MapWeighted[T, R any](ctx context.Context, items []T, weightFn func(item T) int64, fn func(ctx context.Context, item T) (R, error), opts ...GroupOption) ([]R, error) {
+	return mapItems(ctx, items, weightFn, fn, opts)
+}
+
+func mapItems[T, R any](ctx context.Context, items []T, weightFn func(item T) int64, fn func(ctx context.Context, item T) (R, error), opts []GroupOption) ([]R, error) {
 	if len(items) == 0 {
 		return nil, nil
 	}
@@ -18,6 +29,11 @@ func Map[T, R any](ctx context.Context, items []T, fn func(ctx context.Context,
 	g := NewGroup(ctx, opts...)
 
 	for i, item := range items {
+		var addOpts []GoOption
+		if weightFn != nil {
+			addOpts = append(addOpts, WithWeight(weightFn(item)))
+		}
+
 		g.Add(func(ctx context.Context) error {
 			r, err := fn(ctx, item)
 			if err != nil {
@@ -27,7 +43,7 @@ func Map[T, R any](ctx context.Context, items []T, fn func(ctx context.Context,
 			results[i] = r
 
 			return nil
-		})
+		}, addOpts...)
 	}
 
 	return results, g.Wait() //nolint:contextcheck
@@ -10,6 +10,7 @@ import (
 	"github.com/foomo/gofuncy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
 )
 
 func ExampleMap() {
@@ -155,6 +156,45 @@ func TestMap_withLimit(t *testing.T) {
 	}
 }
 
+func TestMapWeighted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		inUse   atomic.Int64
+		maxSeen atomic.Int64
+	)
+
+	// item values are their sizes; at most 10 units may be processed at once
+	items := []int64{6, 4, 3, 7, 5, 5, 2, 8}
+
+	results, err := gofuncy.MapWeighted(t.Context(), items,
+		func(item int64) int64 { return item },
+		func(ctx context.Context, item int64) (int64, error) {
+			cur := inUse.Add(item)
+
+			for {
+				old := maxSeen.Load()
+				if cur <= old || maxSeen.CompareAndSwap(old, cur) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			inUse.Add(-item)
+
+			return item * 2, nil
+		},
+		gofuncy.WithLimiter(semaphore.NewWeighted(10)),
+	)
+
+	require.NoError(t, err)
+	assert.LessOrEqual(t, maxSeen.Load(), int64(10))
+
+	for i, item := range items {
+		assert.Equal(t, item*2, results[i])
+	}
+}
+
 func TestMap_singleItem(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,136 @@
+package gofuncy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Result is the result of a single item of MapResults.
+type Result[R any] struct {
+	// Value is the result of the item. It is the zero value if Err is set.
+	Value R
+	// Err is the error of the item after retries and fallback.
+	Err error
+	// Duration is the time from the start of the first attempt to the end of
+	// the last one, including backoff delays.
+	Duration time.Duration
+	// Attempts is the number of times fn was called; 0 if it never ran, e.g.
+	// because a circuit breaker rejected the item.
+	Attempts int
+}
+
+// ItemError is the error of a single item of MapResults or function of a
+// Group. It is also the context.Cause of a Group cancelled by a failure.
+type ItemError struct {
+	// Index is the index of the item in the input slice.
+	Index int
+	// Name is the routine name the item ran as.
+	Name string
+	Err  error
+}
+
+// Error implements the error interface for ItemError.
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s[%d]: %v", e.Name, e.Index, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// ItemErrors is the error returned by MapResults when items failed, ordered
+// by item index. errors.Is and errors.As match against each entry.
+type ItemErrors []*ItemError
+
+// Error implements the error interface for ItemErrors.
+func (e ItemErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the item errors.
+func (e ItemErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// MapResults transforms items concurrently like Map, but returns a Result per
+// item aligned with the input, so the results of succeeded items are kept
+// and failed items can be told apart. If any item failed, it also returns
+// ItemErrors.
+// All GroupOption options apply (WithLimit, WithFailFast, telemetry, etc.).
+// Use WithName to set a custom metric/tracing label; defaults to "gofuncy.map".
+func MapResults[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error), opts ...GroupOption) ([]Result[R], error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	groupOpts := make([]GroupOption, 0, len(opts)+1)
+	groupOpts = append(groupOpts, WithName("gofuncy.map"))
+	groupOpts = append(groupOpts, opts...)
+
+	results := make([]Result[R], len(items))
+	names := make([]string, len(items))
+
+	g := NewGroup(ctx, groupOpts...)
+
+	for i, item := range items {
+		var start time.Time
+
+		g.Add(func(ctx context.Context) error {
+			if start.IsZero() {
+				start = time.Now()
+				names[i] = NameFromContext(ctx)
+			}
+
+			results[i].Attempts = AttemptFromContext(ctx)
+
+			// deferred so that panicking attempts are accounted for too
+			defer func() { results[i].Duration = time.Since(start) }()
+
+			r, err := fn(ctx, item)
+			results[i].Value = r
+
+			return err
+		})
+	}
+
+	_ = g.Wait() //nolint:contextcheck
+
+	var errs ItemErrors
+
+	for i, err := range g.errs {
+		if err == nil {
+			continue
+		}
+
+		var zero R
+
+		results[i].Value = zero
+		results[i].Err = err
+
+		name := names[i]
+		if name == "" {
+			name = g.o.name
+		}
+
+		errs = append(errs, &ItemError{Index: i, Name: name, Err: err})
+	}
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+
+	return results, nil
+}
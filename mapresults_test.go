@@ -0,0 +1,117 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleMapResults() {
+	ctx := context.Background()
+
+	results, err := gofuncy.MapResults(ctx, []int{1, 0, 3}, func(ctx context.Context, n int) (int, error) {
+		if n == 0 {
+			return 0, errors.New("zero")
+		}
+
+		return 12 / n, nil
+	})
+
+	for _, r := range results {
+		fmt.Println(r.Value, r.Err)
+	}
+
+	fmt.Println(err)
+	// Output:
+	// 12 <nil>
+	// 0 zero
+	// 4 <nil>
+	// gofuncy.map[1]: zero
+}
+
+func TestMapResults(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+
+	results, err := gofuncy.MapResults(t.Context(), []int{1, 2, 3, 4}, func(ctx context.Context, n int) (string, error) {
+		if n%2 == 0 {
+			return "partial", fmt.Errorf("item %d: %w", n, boom)
+		}
+
+		time.Sleep(time.Millisecond)
+
+		return fmt.Sprint(n), nil
+	}, gofuncy.WithName("convert"))
+
+	require.Len(t, results, 4)
+	assert.Equal(t, "1", results[0].Value)
+	assert.Equal(t, "3", results[2].Value)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, 1, results[0].Attempts)
+	assert.GreaterOrEqual(t, results[0].Duration, time.Millisecond)
+
+	assert.Empty(t, results[1].Value, "failed items carry the zero value")
+	require.ErrorIs(t, results[1].Err, boom)
+
+	require.ErrorIs(t, err, boom)
+
+	var itemErrs gofuncy.ItemErrors
+	require.ErrorAs(t, err, &itemErrs)
+	require.Len(t, itemErrs, 2)
+	assert.Equal(t, 1, itemErrs[0].Index)
+	assert.Equal(t, 3, itemErrs[1].Index)
+	assert.Equal(t, "convert", itemErrs[0].Name)
+
+	var itemErr *gofuncy.ItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+}
+
+func TestMapResults_attempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	results, err := gofuncy.MapResults(t.Context(), []int{1}, func(ctx context.Context, n int) (int, error) {
+		if calls.Add(1) < 3 {
+			return 0, errors.New("flaky")
+		}
+
+		return n, nil
+	}, gofuncy.WithRetry(5, gofuncy.RetryBackoff(gofuncy.BackoffConstant(time.Millisecond))))
+	require.NoError(t, err)
+	assert.Equal(t, 1, results[0].Value)
+	assert.Equal(t, 3, results[0].Attempts)
+	assert.GreaterOrEqual(t, results[0].Duration, 2*time.Millisecond)
+}
+
+func TestMapResults_panic(t *testing.T) {
+	t.Parallel()
+
+	results, err := gofuncy.MapResults(t.Context(), []int{1}, func(ctx context.Context, n int) (int, error) {
+		panic("boom")
+	})
+
+	var panicErr *gofuncy.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.ErrorAs(t, results[0].Err, &panicErr)
+	assert.Equal(t, 1, results[0].Attempts)
+}
+
+func TestMapResults_empty(t *testing.T) {
+	t.Parallel()
+
+	results, err := gofuncy.MapResults(t.Context(), []int{}, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	require.NoError(t, err)
+	assert.Nil(t, results)
+}
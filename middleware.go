@@ -3,6 +3,8 @@ package gofuncy
 import (
 	"context"
 	"log/slog"
+	"runtime"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -17,31 +19,44 @@ import (
 	"github.com/foomo/gofuncy/semconv/gofuncyconv"
 )
 
+// withContextInjection injects the routine name, parent and start time. The
+// start time is taken on the first call only, so it stays stable across
+// retry attempts.
 func withContextInjection(fn Func, name string) Func {
+	var startedAt time.Time
+
 	return func(ctx context.Context) error {
-		routineName := NameFromContext(ctx)
-		hasParent := routineName != NameNoName
-		hasName := name != NameNoName
-
-		switch {
-		case hasParent && hasName:
-			ctx = injectRoutineIntoContext(ctx, name, routineName)
-		case hasParent:
-			ctx = injectParentIntoContext(ctx, routineName)
-		case hasName:
-			ctx = injectNameIntoContext(ctx, name)
+		if startedAt.IsZero() {
+			startedAt = time.Now()
 		}
 
-		return fn(ctx)
+		ri := routineInfo{name: name, startedAt: startedAt}
+
+		if routineName := NameFromContext(ctx); routineName != NameNoName {
+			ri.parent = routineName
+
+			if name == NameNoName {
+				ri.name = routineName
+			}
+		}
+
+		return fn(injectRoutineInfoIntoContext(ctx, ri))
 	}
 }
 
-func withTimeout(fn Func, timeout time.Duration) Func {
+func withTimeout(fn Func, timeout time.Duration, tracing bool) Func {
 	return func(ctx context.Context) error {
-		ctx, cancel := context.WithTimeout(ctx, timeout)
+		tctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
-		return fn(ctx)
+		err := fn(tctx)
+
+		// tctx can only be done before cancel() through its own deadline or the parent
+		if tracing && tctx.Err() != nil && ctx.Err() == nil {
+			trace.SpanFromContext(ctx).AddEvent(semconv.EventTimeout, trace.WithAttributes(semconv.Timeout(timeout)))
+		}
+
+		return err
 	}
 }
 
@@ -193,29 +208,79 @@ func withStallDetector(fn Func, threshold time.Duration, handler StallHandler, m
 	}
 }
 
+// operationScope returns the key identifying an operation in state shared
+// across invocations: its name or, for invocations keeping the default name,
+// the name and the call site, so unrelated unnamed calls never share state.
+// callerSkip is the one passed to buildChain.
+func operationScope(name, defaultName string, callerSkip int) string {
+	if name != defaultName {
+		return name
+	}
+
+	// file and line rather than the pc, which differs between inlined copies
+	// of a helper wrapping the call
+	_, file, line, ok := runtime.Caller(callerSkip)
+	if !ok {
+		return name
+	}
+
+	return name + "@" + file + ":" + strconv.Itoa(line)
+}
+
 func buildChain(fn Func, o *options, spanPrefix string, callerSkip int) Func {
+	if o.singleflightFn != nil || o.admission > 0 {
+		o.scope = operationScope(o.name, spanPrefix, callerSkip)
+	}
+
 	run := fn
 	run = withRecover(run)
 
 	// resilience chain (innermost → outermost)
 	if o.timeout > 0 {
-		run = withTimeout(run, o.timeout)
+		run = withTimeout(run, o.timeout, o.tracing)
 	}
 
 	if o.retryAttempts > 1 {
-		opts := make([]RetryOption, len(o.retryOpts)+1)
+		opts := make([]RetryOption, len(o.retryOpts), len(o.retryOpts)+2)
 		copy(opts, o.retryOpts)
-		opts[len(o.retryOpts)] = retryWithMeter(o.meter(), o.name)
+		opts = append(opts, retryWithMeter(o.meter(), o.name))
+
+		if o.tracing {
+			opts = append(opts, retryWithTracer(o.tracer()))
+		}
 
 		run = Retry(o.retryAttempts, opts...)(run)
 	}
 
 	if o.circuitBreaker != nil {
-		run = o.circuitBreaker.middleware(o.meter(), o.name)(run)
+		run = o.circuitBreaker.middleware(o.meter(), o.name, o.tracing)(run)
+	}
+
+	if o.cache != nil {
+		refreshOpts := []GoOption{
+			WithName(o.name),
+			WithLogger(o.l),
+			WithMeterProvider(o.meterProvider),
+			WithTracerProvider(o.tracerProvider),
+		}
+		if !o.tracing {
+			refreshOpts = append(refreshOpts, WithoutTracing())
+		}
+
+		run = o.cache.middleware(o.meter(), o.name, o.cacheKeyFn, o.result, refreshOpts)(run)
 	}
 
 	if o.fallbackFn != nil {
-		run = Fallback(o.fallbackFn, o.fallbackOpts...)(run)
+		opts := o.fallbackOpts
+		if o.tracing {
+			opts = append(opts[:len(opts):len(opts)], fallbackWithTracing())
+		}
+
+		run = Fallback(o.fallbackFn, opts...)(run)
+	}
+
+	if o.totalTimeout > 0 {
+		run = withTimeout(run, o.totalTimeout, o.tracing)
 	}
 
 	// user middlewares
@@ -223,6 +288,20 @@ func buildChain(fn Func, o *options, spanPrefix string, callerSkip int) Func {
 		run = m(run)
 	}
 
+	if o.singleflightFn != nil {
+		run = withSingleflight(run, o.singleflightFn, o.result, o.meter(), o.name, o.scope)
+	}
+
+	if obs, ok := o.limiter.(LimiterObserver); ok {
+		run = withLimiterFeedback(run, obs)
+	}
+
+	if o.admission > 0 {
+		run = withLatencyRecording(run, o.scope)
+	}
+
+	run = withCancelCause(run)
+
 	if o.startedCounter || o.errorCounter || o.activeUpDownCounter || o.durationHistogram {
 		m := o.meter()
 
@@ -255,6 +334,8 @@ func buildChain(fn Func, o *options, spanPrefix string, callerSkip int) Func {
 }
 
 func handleError(ctx context.Context, err error, handler ErrorHandler, l *slog.Logger, name string) {
+	err = withCause(ctx, err)
+
 	if handler != nil {
 		handler(ctx, err)
 		return
@@ -81,7 +81,7 @@ func TestWithTimeout_completesBeforeDeadline(t *testing.T) {
 
 	fn := withTimeout(func(ctx context.Context) error {
 		return nil
-	}, time.Second)
+	}, time.Second, false)
 
 	require.NoError(t, fn(context.Background()))
 }
@@ -92,7 +92,7 @@ func TestWithTimeout_exceedsDeadline(t *testing.T) {
 	fn := withTimeout(func(ctx context.Context) error {
 		<-ctx.Done()
 		return ctx.Err()
-	}, time.Millisecond)
+	}, time.Millisecond, false)
 
 	err := fn(context.Background())
 	require.ErrorIs(t, err, context.DeadlineExceeded)
@@ -501,7 +501,7 @@ func TestWithTimeout_zeroValue(t *testing.T) {
 	// context.WithTimeout(ctx, 0) creates an immediately-expired context.
 	fn := withTimeout(func(ctx context.Context) error {
 		return ctx.Err()
-	}, 0)
+	}, 0, false)
 
 	err := fn(context.Background())
 	require.ErrorIs(t, err, context.DeadlineExceeded)
@@ -3,13 +3,13 @@ package gofuncy
 import (
 	"context"
 	"log/slog"
+	"reflect"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	otelsemconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/sync/semaphore"
 )
 
 // GoOption configures Go() and Group.Add() calls.
@@ -50,6 +50,7 @@ type options struct { //nolint:recvcheck
 	l              *slog.Logger
 	name           string
 	timeout        time.Duration
+	totalTimeout   time.Duration
 	callerSkip     int
 	errorHandler   ErrorHandler
 	stallThreshold time.Duration
@@ -66,18 +67,36 @@ type options struct { //nolint:recvcheck
 	retryAttempts  int
 	retryOpts      []RetryOption
 	circuitBreaker *CircuitBreaker
+	loadShedder    *LoadShedder
+	admission      float64
 	fallbackFn     func(context.Context, error) error
 	fallbackOpts   []FallbackOption
+	singleflightFn func(context.Context) string
+	cache          *Cache
+	cacheKeyFn     func(context.Context) string
+	result         *resultSlot
+	// operation scope of shared state, set by buildChain (see operationScope)
+	scope string
 	// middleware
 	middlewares []Middleware
 	// telemetry providers
 	meterProvider  metric.MeterProvider
 	tracerProvider trace.TracerProvider
 	// concurrency
-	limiter *semaphore.Weighted
+	limiter        Limiter
+	weight         int64
+	limiterTimeout time.Duration
 	// group-specific
-	limit    int
-	failFast bool
+	limit           int
+	failFast        bool
+	failFastIf      func(error) bool
+	maxFailures     int
+	maxFailureRatio float64
+	errorMode       ErrorMode
+	keepStragglers  bool
+	longLived       bool
+	queueSize       int
+	queuePolicy     QueuePolicy
 }
 
 // meter returns the OTel Meter for this scope. The OTel SDK caches both Meter
@@ -91,6 +110,15 @@ func (o *options) meter() metric.Meter {
 	return mp.Meter(ScopeName, metric.WithSchemaURL(otelsemconv.SchemaURL))
 }
 
+// limiterWeight returns the weight acquired from the limiter per execution.
+func (o *options) limiterWeight() int64 {
+	if o.weight > 0 {
+		return o.weight
+	}
+
+	return 1
+}
+
 // tracer returns the OTel Tracer for this scope. The OTel SDK caches Tracer
 // instances internally, so repeated calls are cheap.
 func (o *options) tracer() trace.Tracer {
@@ -114,6 +142,10 @@ func (o options) merge(override options) options {
 		o.l = override.l
 	}
 
+	if override.errorHandler != nil {
+		o.errorHandler = override.errorHandler
+	}
+
 	if len(override.middlewares) > 0 {
 		merged := make([]Middleware, len(o.middlewares)+len(override.middlewares))
 		copy(merged, o.middlewares)
@@ -125,6 +157,10 @@ func (o options) merge(override options) options {
 		o.timeout = override.timeout
 	}
 
+	if override.totalTimeout > 0 {
+		o.totalTimeout = override.totalTimeout
+	}
+
 	if override.stallThreshold > 0 {
 		o.stallThreshold = override.stallThreshold
 	}
@@ -153,6 +189,14 @@ func (o options) merge(override options) options {
 		o.limiter = override.limiter
 	}
 
+	if override.weight > 0 {
+		o.weight = override.weight
+	}
+
+	if override.limiterTimeout > 0 {
+		o.limiterTimeout = override.limiterTimeout
+	}
+
 	if override.retryAttempts > 0 {
 		o.retryAttempts = override.retryAttempts
 		o.retryOpts = override.retryOpts
@@ -162,11 +206,28 @@ func (o options) merge(override options) options {
 		o.circuitBreaker = override.circuitBreaker
 	}
 
+	if override.loadShedder != nil {
+		o.loadShedder = override.loadShedder
+	}
+
+	if override.admission > 0 {
+		o.admission = override.admission
+	}
+
 	if override.fallbackFn != nil {
 		o.fallbackFn = override.fallbackFn
 		o.fallbackOpts = override.fallbackOpts
 	}
 
+	if override.cache != nil {
+		o.cache = override.cache
+		o.cacheKeyFn = override.cacheKeyFn
+	}
+
+	if override.singleflightFn != nil {
+		o.singleflightFn = override.singleflightFn
+	}
+
 	return o
 }
 
@@ -318,13 +379,38 @@ func WithTracerProvider(tp trace.TracerProvider) baseOpt {
 	}
 }
 
-// WithLimiter sets a shared weighted semaphore for concurrency control.
-func WithLimiter(l *semaphore.Weighted) baseOpt {
+// WithLimiter sets a shared limiter for concurrency control, e.g. a
+// *semaphore.Weighted or an *AdaptiveLimiter. A nil limiter, including a nil
+// pointer of a limiter type, means no limiter.
+func WithLimiter(l Limiter) baseOpt {
+	if v := reflect.ValueOf(l); v.Kind() == reflect.Pointer && v.IsNil() {
+		l = nil
+	}
+
 	return func(o *options) {
 		o.limiter = l
 	}
 }
 
+// WithWeight sets the weight acquired from the WithLimiter limiter per
+// execution, so limits can bound memory or connections rather than goroutine
+// counts. Defaults to 1. It does not affect the goroutine count bound of
+// WithLimit.
+func WithWeight(n int64) baseOpt {
+	return func(o *options) {
+		o.weight = n
+	}
+}
+
+// WithLimiterTimeout bounds how long an invocation waits for the WithLimiter
+// limiter. If the limiter cannot be acquired within d, the invocation is not
+// started and fails with ErrLimiterTimeout.
+func WithLimiterTimeout(d time.Duration) baseOpt {
+	return func(o *options) {
+		o.limiterTimeout = d
+	}
+}
+
 // WithStallThreshold enables stall detection. If a goroutine runs longer than
 // the threshold, a warning is logged and a metric is emitted. The goroutine is
 // not cancelled. Use WithStallHandler to customize the callback.
@@ -350,6 +436,15 @@ func WithTimeout(timeout time.Duration) baseOpt {
 	}
 }
 
+// WithTotalTimeout sets a deadline covering the whole resilience chain:
+// all retry attempts, backoff delays, circuit breaker, and fallback. Use it
+// together with WithTimeout to bound both each attempt and the overall call.
+func WithTotalTimeout(timeout time.Duration) baseOpt {
+	return func(o *options) {
+		o.totalTimeout = timeout
+	}
+}
+
 // WithRetry configures automatic retry with the given maximum attempts.
 // maxAttempts is the total number of attempts (1 = no retry, 3 = initial + 2 retries).
 func WithRetry(maxAttempts int, opts ...RetryOption) baseOpt {
@@ -368,6 +463,30 @@ func WithCircuitBreaker(cb *CircuitBreaker) baseOpt {
 	}
 }
 
+// WithLoadShedder sets a load shedder for the operation. Invocations are
+// rejected with ErrOverloaded while the shedder is overloaded, before waiting
+// for the WithLimiter limiter, and the WithLimiter wait of every invocation is
+// reported as its queueing delay. The shedder is stateful — create one via
+// NewLoadShedder and share it.
+func WithLoadShedder(s *LoadShedder) baseOpt {
+	return func(o *options) {
+		o.loadShedder = s
+	}
+}
+
+// WithDeadlineAdmission rejects invocations whose remaining context deadline
+// is shorter than the given percentile (e.g. 0.9) of recent durations of
+// successful invocations of the same operation. Durations are shared by
+// WithName; without WithName, only calls from the same call site share them.
+// Rejected invocations fail with a *DeadlineAdmissionError before fn runs and
+// before waiting for the WithLimiter limiter. Admission starts once a few
+// durations have been observed; invocations without a deadline always run.
+func WithDeadlineAdmission(percentile float64) baseOpt {
+	return func(o *options) {
+		o.admission = percentile
+	}
+}
+
 // WithFallback sets a fallback function that is called when the operation fails.
 // The fallback receives the original error and may return nil to suppress it or
 // a different error.
@@ -378,6 +497,31 @@ func WithFallback(fn func(ctx context.Context, err error) error, opts ...Fallbac
 	}
 }
 
+// WithCache serves results from the given Cache, keyed by keyFn. The cache is
+// stateful — create one via NewCache and share it across all calls to the same
+// operation. It sits between the circuit breaker and the fallback, so a hit
+// skips timeouts, retries, and the circuit breaker entirely.
+func WithCache(c *Cache, keyFn func(ctx context.Context) string) baseOpt {
+	return func(o *options) {
+		o.cache = c
+		o.cacheKeyFn = keyFn
+	}
+}
+
+// WithSingleflight collapses concurrent invocations that share a key into a
+// single execution of the full chain (resilience and user middlewares). All
+// waiters receive the same result and error. Keys are scoped by WithName and
+// the result type; without WithName, only calls from the same call site are
+// collapsed. The execution runs with the first caller's context; if it is
+// cancelled, all waiters see the error. The first caller waits for the
+// execution even once its context is done, so its WithLimiter slot is held
+// until the execution returned; other callers stop waiting.
+func WithSingleflight(keyFn func(ctx context.Context) string) baseOpt {
+	return func(o *options) {
+		o.singleflightFn = keyFn
+	}
+}
+
 // ------------------------------------------------------------------------------------------------
 // ~ Go-only options (Go, Add)
 // ------------------------------------------------------------------------------------------------
@@ -413,3 +557,73 @@ func WithFailFast() groupOnlyOpt {
 		o.failFast = true
 	}
 }
+
+// WithFailFastIf configures the Group to cancel remaining functions on the
+// first error for which fn returns true. Other errors, e.g. expected
+// not-found errors, are still returned by Wait but do not cancel siblings.
+func WithFailFastIf(fn func(err error) bool) groupOnlyOpt {
+	return func(o *options) {
+		o.failFast = true
+		o.failFastIf = fn
+	}
+}
+
+// WithFailureTolerance configures the Group to cancel remaining functions
+// once more than n functions failed. Combined with WithFailFastIf, only
+// matching errors are counted.
+func WithFailureTolerance(n int) groupOnlyOpt {
+	return func(o *options) {
+		o.failFast = true
+		o.maxFailures = n
+	}
+}
+
+// WithFailureRatio configures the Group to cancel remaining functions once
+// the ratio of failed to added functions exceeds r. Combined with
+// WithFailureTolerance, both must be exceeded, so a few early failures do
+// not cancel the group.
+func WithFailureRatio(r float64) groupOnlyOpt {
+	return func(o *options) {
+		o.failFast = true
+		o.maxFailureRatio = r
+	}
+}
+
+// WithErrorMode sets how Wait reports the errors of the Group.
+// Defaults to ErrorModeJoin.
+func WithErrorMode(m ErrorMode) groupOnlyOpt {
+	return func(o *options) {
+		o.errorMode = m
+	}
+}
+
+// WithLongLived turns the Group into a long-lived task set, e.g. for the
+// background work of a service. Errors are not collected for Wait but
+// passed to the error handler, like with Go, so memory stays bounded.
+// Instead of a span covering the whole group, the active and queued
+// functions are reported as gauges on every metric collection.
+// Use WaitIdle to wait for the current functions and Close to shut down.
+func WithLongLived() groupOnlyOpt {
+	return func(o *options) {
+		o.longLived = true
+	}
+}
+
+// WithQueue makes Group.Add enqueue functions into a queue of the given size
+// instead of blocking for a slot. The queue is serviced by up to WithLimit
+// workers; policy decides what happens when the queue is full.
+func WithQueue(size int, policy QueuePolicy) groupOnlyOpt {
+	return func(o *options) {
+		o.queueSize = size
+		o.queuePolicy = policy
+	}
+}
+
+// WithKeepStragglers lets items still running at the soft deadline of Gather
+// finish in the background instead of cancelling them. Their results are
+// discarded.
+func WithKeepStragglers() groupOnlyOpt {
+	return func(o *options) {
+		o.keepStragglers = true
+	}
+}
@@ -0,0 +1,221 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/gofuncy/semconv"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// ------------------------------------------------------------------------------------------------
+// ~ Retry: per-attempt spans
+// ------------------------------------------------------------------------------------------------
+
+func TestRetry_attemptSpans(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	var calls int
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+
+		return nil
+	},
+		gofuncy.WithName("fetch"),
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithRetry(3, gofuncy.RetryBackoff(gofuncy.BackoffConstant(time.Millisecond))),
+	)
+	require.NoError(t, err)
+
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+	parent := findSpan(t, spans, "gofuncy.do fetch")
+
+	var attempts []tracetest.SpanStub
+
+	for _, s := range spans {
+		if s.Name == "gofuncy.retry.attempt fetch" {
+			attempts = append(attempts, s)
+		}
+	}
+
+	require.Len(t, attempts, 3)
+
+	for _, a := range attempts {
+		assert.Equal(t, parent.SpanContext.SpanID(), a.Parent.SpanID())
+
+		attempt, ok := findAttr(a.Attributes, semconv.RetryAttemptKey)
+		require.True(t, ok)
+
+		backoff, ok := findAttr(a.Attributes, semconv.RetryBackoffKey)
+		require.True(t, ok)
+
+		if attempt.AsInt64() == 1 {
+			assert.Zero(t, backoff.AsFloat64())
+		} else {
+			assert.InDelta(t, time.Millisecond.Seconds(), backoff.AsFloat64(), 1e-9)
+		}
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ Span events
+// ------------------------------------------------------------------------------------------------
+
+func TestCircuitBreaker_spanEvents(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	cb := gofuncy.NewCircuitBreaker(
+		gofuncy.CircuitBreakerThreshold(1),
+		gofuncy.CircuitBreakerCooldown(time.Hour),
+	)
+
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("dep"),
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithCircuitBreaker(cb),
+	}
+
+	require.Error(t, gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		return errors.New("fail")
+	}, opts...))
+	require.ErrorIs(t, gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		return nil
+	}, opts...), gofuncy.ErrCircuitOpen)
+
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+
+	changed := findEvent(t, spans[0].Events, semconv.EventCircuitStateChange)
+	from, _ := findAttr(changed.Attributes, semconv.CircuitStateFromKey)
+	to, _ := findAttr(changed.Attributes, semconv.CircuitStateKey)
+	assert.Equal(t, "closed", from.AsString())
+	assert.Equal(t, "open", to.AsString())
+
+	findEvent(t, spans[1].Events, semconv.EventCircuitRejected)
+}
+
+func TestTimeout_spanEvent(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	},
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithTimeout(5*time.Millisecond),
+	)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+
+	event := findEvent(t, spans[0].Events, semconv.EventTimeout)
+	timeout, ok := findAttr(event.Attributes, semconv.TimeoutKey)
+	require.True(t, ok)
+	assert.InDelta(t, 0.005, timeout.AsFloat64(), 1e-9)
+}
+
+func TestFallback_spanEvent(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		return errors.New("primary down")
+	},
+		gofuncy.WithTracerProvider(tp),
+		gofuncy.WithFallback(func(ctx context.Context, err error) error {
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+
+	findEvent(t, spans[0].Events, semconv.EventFallback)
+}
+
+func TestFallback_noSpanEventWithoutTracing(t *testing.T) {
+	t.Parallel()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := oteltesting.ReportTraces(t, exp)
+
+	ctx, parent := tp.Tracer("test").Start(t.Context(), "parent")
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		return errors.New("primary down")
+	},
+		gofuncy.WithoutTracing(),
+		gofuncy.WithFallback(func(ctx context.Context, err error) error {
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	parent.End()
+	tp.ForceFlush(t.Context())
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Events)
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ Helpers
+// ------------------------------------------------------------------------------------------------
+
+func findEvent(t *testing.T, events []sdktrace.Event, name string) sdktrace.Event {
+	t.Helper()
+
+	for _, e := range events {
+		if e.Name == name {
+			return e
+		}
+	}
+
+	t.Fatalf("event %q not found", name)
+
+	return sdktrace.Event{}
+}
+
+func findAttr(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
@@ -0,0 +1,97 @@
+package gofuncy
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/foomo/gofuncy/semconv"
+)
+
+// ResizableLimiterOption configures a ResizableLimiter.
+type ResizableLimiterOption func(*resizableLimiterConfig)
+
+type resizableLimiterConfig struct {
+	name          string
+	meterProvider metric.MeterProvider
+}
+
+// ResizableLimiter is a Limiter whose limit can be changed at runtime with
+// SetLimit, e.g. to throttle or widen concurrency during an incident without
+// a restart. Pass it to WithLimiter on Do, Go, NewGroup, Map, etc. It is safe
+// for concurrent use.
+type ResizableLimiter struct {
+	fifoLimiter
+
+	cfg resizableLimiterConfig
+	reg metric.Registration
+}
+
+// NewResizableLimiter creates a new ResizableLimiter admitting up to limit
+// executions and registers its limit and in-flight gauges. A limit of 0 or
+// less means no limit.
+func NewResizableLimiter(limit int, opts ...ResizableLimiterOption) *ResizableLimiter {
+	l := &ResizableLimiter{
+		fifoLimiter: fifoLimiter{limit: int64(limit)},
+		cfg: resizableLimiterConfig{
+			name: "gofuncy.limiter",
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&l.cfg)
+	}
+
+	l.reg = l.registerGauges(l.cfg.meterProvider, l.cfg.name)
+
+	return l
+}
+
+// SetLimit changes the limit; 0 or less means no limit. A larger limit
+// admits queued waiters immediately. A smaller one lets executions in flight
+// complete and applies to all later admissions.
+func (l *ResizableLimiter) SetLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = int64(n)
+
+	l.notify()
+}
+
+// Limit returns the current limit; 0 means no limit.
+func (l *ResizableLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(max(l.limit, 0))
+}
+
+func (l *ResizableLimiter) waitAttributes(context.Context) []attribute.KeyValue {
+	return []attribute.KeyValue{semconv.LimiterName(l.cfg.name)}
+}
+
+// Close unregisters the limiter's gauges.
+func (l *ResizableLimiter) Close() error {
+	if l.reg == nil {
+		return nil
+	}
+
+	return l.reg.Unregister()
+}
+
+// ResizableLimiterName sets the name reported with the limiter's gauges.
+// Defaults to "gofuncy.limiter".
+func ResizableLimiterName(name string) ResizableLimiterOption {
+	return func(c *resizableLimiterConfig) {
+		c.name = name
+	}
+}
+
+// ResizableLimiterMeterProvider sets a custom meter provider for the gauges.
+func ResizableLimiterMeterProvider(mp metric.MeterProvider) ResizableLimiterOption {
+	return func(c *resizableLimiterConfig) {
+		c.meterProvider = mp
+	}
+}
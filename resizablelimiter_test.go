@@ -0,0 +1,78 @@
+package gofuncy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResizableLimiter(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	l := gofuncy.NewResizableLimiter(1,
+		gofuncy.ResizableLimiterName("payments"),
+		gofuncy.ResizableLimiterMeterProvider(mp),
+	)
+	defer l.Close()
+
+	require.NoError(t, l.Acquire(t.Context(), 1))
+	assert.False(t, l.TryAcquire(1))
+
+	acquired := make(chan error, 1)
+
+	go func() {
+		acquired <- l.Acquire(t.Context(), 1)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquire must block at the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// widening admits the queued waiter
+	l.SetLimit(2)
+	require.NoError(t, <-acquired)
+	assert.Equal(t, 2, l.Limit())
+	assert.Equal(t, 2, l.InFlight())
+
+	// narrowing lets executions in flight complete
+	l.SetLimit(1)
+	assert.Equal(t, 2, l.InFlight())
+
+	l.Release(1)
+	assert.False(t, l.TryAcquire(1))
+
+	l.Release(1)
+	assert.True(t, l.TryAcquire(1))
+
+	// no limit
+	l.SetLimit(0)
+	assert.True(t, l.TryAcquire(5))
+	assert.Equal(t, 0, l.Limit())
+}
+
+func TestResizableLimiter_cancel(t *testing.T) {
+	t.Parallel()
+
+	l := gofuncy.NewResizableLimiter(1)
+	defer l.Close()
+
+	require.NoError(t, l.Acquire(t.Context(), 1))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, l.Acquire(ctx, 1), context.DeadlineExceeded)
+
+	l.Release(1)
+	assert.Zero(t, l.InFlight())
+}
@@ -3,32 +3,36 @@ package gofuncy
 import (
 	"context"
 	"errors"
-	"math"
-	"math/rand/v2"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/foomo/gofuncy/semconv"
 	"github.com/foomo/gofuncy/semconv/gofuncyconv"
 )
 
-// Backoff returns the delay before the nth retry attempt (0-indexed).
-type Backoff func(attempt int) time.Duration
-
 // RetryOption configures retry behavior.
 type RetryOption func(*retryConfig)
 
 type retryConfig struct {
-	backoff func(attempt int) time.Duration
-	retryIf func(error) bool
-	onRetry func(ctx context.Context, attempt int, err error)
-	meter   metric.Meter
-	name    string
+	backoff    func(attempt int) time.Duration
+	retryIf    func(error) bool
+	maxHint    time.Duration
+	maxElapsed time.Duration
+	minBudget  time.Duration
+	onRetry    func(ctx context.Context, attempt int, err error)
+	meter      metric.Meter
+	tracer     trace.Tracer
+	name       string
 }
 
 // Retry returns a Middleware that retries the wrapped function up to
 // maxAttempts times total (1 = no retry, 3 = initial + up to 2 retries).
+// Errors marked via Permanent are never retried. Errors implementing
+// RetryAfterHint override the backoff delay, capped by RetryMaxHint.
 func Retry(maxAttempts int, opts ...RetryOption) Middleware {
 	if maxAttempts < 1 {
 		maxAttempts = 1
@@ -37,6 +41,7 @@ func Retry(maxAttempts int, opts ...RetryOption) Middleware {
 	cfg := retryConfig{
 		backoff: BackoffExponential(100*time.Millisecond, 2, 30*time.Second),
 		retryIf: defaultRetryIf,
+		maxHint: 30 * time.Second,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
@@ -49,14 +54,23 @@ func Retry(maxAttempts int, opts ...RetryOption) Middleware {
 
 	return func(fn Func) Func {
 		return func(ctx context.Context) error {
-			var err error
+			var (
+				err   error
+				delay time.Duration
+				start = time.Now()
+			)
+
+			if err := cfg.budgetExhausted(ctx, 0, nil, 0); err != nil {
+				return err
+			}
+
 			for attempt := 0; attempt < maxAttempts; attempt++ {
-				err = fn(ctx)
+				err = cfg.attempt(injectAttemptIntoContext(ctx, attempt+1, maxAttempts), fn, attempt, delay)
 				if err == nil {
 					return nil
 				}
 
-				if !cfg.retryIf(err) {
+				if IsPermanent(err) || !cfg.retryIf(err) {
 					return err
 				}
 
@@ -64,14 +78,24 @@ func Retry(maxAttempts int, opts ...RetryOption) Middleware {
 					break
 				}
 
+				delay = cfg.delay(attempt, err)
+
+				if cfg.maxElapsed > 0 {
+					if elapsed := time.Since(start); elapsed+delay > cfg.maxElapsed {
+						return &RetryElapsedError{Err: err, Attempts: attempt + 1, Elapsed: elapsed}
+					}
+				}
+
+				if err := cfg.budgetExhausted(ctx, delay, err, attempt+1); err != nil {
+					return err
+				}
+
 				retries.Add(ctx, 1, cfg.name)
 
 				if cfg.onRetry != nil {
 					cfg.onRetry(ctx, attempt+1, err)
 				}
 
-				delay := cfg.backoff(attempt)
-
 				t := time.NewTimer(delay)
 				select {
 				case <-ctx.Done():
@@ -108,26 +132,93 @@ func RetryOnRetry(fn func(ctx context.Context, attempt int, err error)) RetryOpt
 	}
 }
 
-// BackoffConstant returns a Backoff that always waits the same duration.
-func BackoffConstant(d time.Duration) Backoff {
-	return func(_ int) time.Duration {
-		return d
+// RetryMaxElapsed sets a total time budget for all attempts and backoff
+// delays. Once the next delay would exceed the budget, Retry gives up and
+// returns a *RetryElapsedError wrapping the last attempt's error. A running
+// attempt is never interrupted; combine with WithTimeout to bound attempts.
+func RetryMaxElapsed(d time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.maxElapsed = d
+	}
+}
+
+// RetryMinBudget skips attempts that would start with less than d left until
+// the context deadline, returning a *DeadlineBudgetError that matches
+// ErrDeadlineBudgetExhausted and wraps the last attempt's error, if any. Has
+// no effect on contexts without a deadline. Disabled by default.
+func RetryMinBudget(d time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.minBudget = d
 	}
 }
 
-// BackoffExponential returns a Backoff with exponential growth, jitter, and a cap.
-// The delay for attempt n is: min(initial * multiplier^n, max) +/- 25% jitter.
-func BackoffExponential(initial time.Duration, multiplier float64, maxDelay time.Duration) Backoff {
-	return func(attempt int) time.Duration {
-		delay := float64(initial) * math.Pow(multiplier, float64(attempt))
-		if delay > float64(maxDelay) {
-			delay = float64(maxDelay)
+// RetryMaxHint caps the delay taken from errors implementing RetryAfterHint.
+// Defaults to 30s.
+func RetryMaxHint(d time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.maxHint = d
+	}
+}
+
+// delay returns the wait before the next attempt, preferring a hint carried
+// by err over the configured backoff.
+func (c *retryConfig) delay(attempt int, err error) time.Duration {
+	if d, ok := retryAfterFromError(err); ok {
+		if c.maxHint > 0 && d > c.maxHint {
+			return c.maxHint
 		}
 
-		jitter := delay * 0.25
-		delay = delay - jitter + rand.Float64()*2*jitter //nolint:gosec
+		return d
+	}
+
+	return c.backoff(attempt)
+}
+
+// budgetExhausted returns a *DeadlineBudgetError if an attempt starting after
+// wait would have less than the configured minimum budget left until the
+// context deadline.
+func (c *retryConfig) budgetExhausted(ctx context.Context, wait time.Duration, lastErr error, attempts int) error {
+	if c.minBudget <= 0 {
+		return nil
+	}
+
+	remaining, ok := RemainingBudgetFromContext(ctx)
+	if !ok || remaining-wait >= c.minBudget {
+		return nil
+	}
+
+	return &DeadlineBudgetError{Err: lastErr, Attempts: attempts, Remaining: max(remaining-wait, 0), MinBudget: c.minBudget}
+}
+
+// attempt runs fn once, wrapped in a child span when a tracer is configured.
+func (c *retryConfig) attempt(ctx context.Context, fn Func, attempt int, delay time.Duration) error {
+	if c.tracer == nil {
+		return fn(ctx)
+	}
+
+	spanName := "gofuncy.retry.attempt"
+	if c.name != "" {
+		spanName += " " + c.name
+	}
+
+	ctx, span := c.tracer.Start(ctx, spanName, trace.WithAttributes(
+		semconv.RetryAttempt(attempt+1),
+		semconv.RetryBackoff(delay),
+	))
+	defer span.End()
+
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
 
-		return time.Duration(delay)
+	return err
+}
+
+func retryWithTracer(t trace.Tracer) RetryOption {
+	return func(c *retryConfig) {
+		c.tracer = t
 	}
 }
 
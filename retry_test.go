@@ -328,3 +328,151 @@ func TestBackoffConstant(t *testing.T) {
 	assert.Equal(t, 50*time.Millisecond, b(5))
 	assert.Equal(t, 50*time.Millisecond, b(100))
 }
+
+func TestRetry_permanentStopsImmediately(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	errNotFound := errors.New("not found")
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		calls.Add(1)
+		return gofuncy.Permanent(errNotFound)
+	}, gofuncy.WithRetry(5,
+		gofuncy.RetryBackoff(gofuncy.BackoffConstant(0)),
+		gofuncy.RetryIf(func(err error) bool { return true }),
+	))
+
+	require.ErrorIs(t, err, errNotFound)
+	assert.True(t, gofuncy.IsPermanent(err))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetry_honoursRetryAfterHint(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	start := time.Now()
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		if calls.Add(1) == 1 {
+			return gofuncy.RetryableAfter(errors.New("throttled"), 30*time.Millisecond)
+		}
+
+		return nil
+	}, gofuncy.WithRetry(3, gofuncy.RetryBackoff(gofuncy.BackoffConstant(time.Hour))))
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetry_capsRetryAfterHint(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	start := time.Now()
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		if calls.Add(1) == 1 {
+			return gofuncy.RetryableAfter(errors.New("throttled"), time.Hour)
+		}
+
+		return nil
+	}, gofuncy.WithRetry(3, gofuncy.RetryMaxHint(10*time.Millisecond)))
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetry_maxElapsed(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	errTransient := errors.New("transient")
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		calls.Add(1)
+		return errTransient
+	}, gofuncy.WithRetry(100,
+		gofuncy.RetryBackoff(gofuncy.BackoffConstant(20*time.Millisecond)),
+		gofuncy.RetryMaxElapsed(50*time.Millisecond),
+	))
+
+	require.ErrorIs(t, err, gofuncy.ErrRetryMaxElapsed)
+	require.ErrorIs(t, err, errTransient)
+
+	var elapsedErr *gofuncy.RetryElapsedError
+	require.ErrorAs(t, err, &elapsedErr)
+	assert.Equal(t, calls.Load(), int32(elapsedErr.Attempts))
+	assert.Less(t, elapsedErr.Attempts, 5)
+	assert.LessOrEqual(t, elapsedErr.Elapsed, 50*time.Millisecond)
+}
+
+func TestRetry_minBudgetSkipsAttempt(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	errTransient := errors.New("transient")
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		calls.Add(1)
+		return errTransient
+	}, gofuncy.WithRetry(5,
+		gofuncy.RetryBackoff(gofuncy.BackoffConstant(60*time.Millisecond)),
+		gofuncy.RetryMinBudget(50*time.Millisecond),
+	))
+
+	require.ErrorIs(t, err, gofuncy.ErrDeadlineBudgetExhausted)
+	require.ErrorIs(t, err, errTransient)
+	assert.Equal(t, int32(1), calls.Load())
+
+	var budgetErr *gofuncy.DeadlineBudgetError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, 1, budgetErr.Attempts)
+	assert.Equal(t, 50*time.Millisecond, budgetErr.MinBudget)
+}
+
+func TestRetry_minBudgetSkipsFirstAttempt(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		t.Fatal("should not be called")
+		return nil
+	}, gofuncy.WithRetry(3, gofuncy.RetryMinBudget(time.Second)))
+
+	require.ErrorIs(t, err, gofuncy.ErrDeadlineBudgetExhausted)
+}
+
+func TestRetry_minBudgetWithoutDeadline(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		if calls.Add(1) < 3 {
+			return errors.New("transient")
+		}
+
+		return nil
+	}, gofuncy.WithRetry(3,
+		gofuncy.RetryBackoff(gofuncy.BackoffConstant(0)),
+		gofuncy.RetryMinBudget(time.Hour),
+	))
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
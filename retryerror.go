@@ -0,0 +1,149 @@
+package gofuncy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRetryMaxElapsed is matched by errors returned when the RetryMaxElapsed
+// budget is exhausted.
+var ErrRetryMaxElapsed = errors.New("retry max elapsed time exceeded")
+
+// ErrDeadlineBudgetExhausted is matched by errors returned when the remaining
+// context deadline is shorter than the RetryMinBudget required to start an
+// attempt.
+var ErrDeadlineBudgetExhausted = errors.New("context deadline too close to start attempt")
+
+// RetryAfterHint is implemented by errors that know when the operation may be
+// retried, e.g. HTTP 429/503 responses carrying a Retry-After header. Retry
+// uses the hinted delay instead of asking its Backoff.
+type RetryAfterHint interface {
+	RetryAfter() time.Duration
+}
+
+// PermanentError marks an error as non-retryable. Retry stops immediately
+// when it encounters one, regardless of the configured RetryIf.
+type PermanentError struct {
+	Err error
+}
+
+// Error implements the error interface for PermanentError.
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfterError wraps an error with a server-provided retry delay.
+type RetryAfterError struct {
+	Err   error
+	Delay time.Duration
+}
+
+// Error implements the error interface for RetryAfterError.
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %s)", e.Err, e.Delay)
+}
+
+// Unwrap returns the wrapped error.
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter implements RetryAfterHint.
+func (e *RetryAfterError) RetryAfter() time.Duration {
+	return e.Delay
+}
+
+// RetryElapsedError is returned by Retry when the RetryMaxElapsed budget is
+// exhausted. It wraps both ErrRetryMaxElapsed and the last attempt's error.
+type RetryElapsedError struct {
+	Err      error
+	Attempts int
+	Elapsed  time.Duration
+}
+
+// Error implements the error interface for RetryElapsedError.
+func (e *RetryElapsedError) Error() string {
+	return fmt.Sprintf("%s after %d attempts in %s: %v", ErrRetryMaxElapsed, e.Attempts, e.Elapsed, e.Err)
+}
+
+// Unwrap returns ErrRetryMaxElapsed and the last attempt's error.
+func (e *RetryElapsedError) Unwrap() []error {
+	return []error{ErrRetryMaxElapsed, e.Err}
+}
+
+// DeadlineBudgetError is returned by Retry when the remaining context deadline
+// is shorter than the RetryMinBudget required to start an attempt. It wraps
+// both ErrDeadlineBudgetExhausted and the last attempt's error, if any.
+type DeadlineBudgetError struct {
+	Err       error
+	Attempts  int
+	Remaining time.Duration
+	MinBudget time.Duration
+}
+
+// Error implements the error interface for DeadlineBudgetError.
+func (e *DeadlineBudgetError) Error() string {
+	msg := fmt.Sprintf("%s: %s left, %s required", ErrDeadlineBudgetExhausted, e.Remaining, e.MinBudget)
+	if e.Err == nil {
+		return msg
+	}
+
+	return fmt.Sprintf("%s after %d attempts: %v", msg, e.Attempts, e.Err)
+}
+
+// Unwrap returns ErrDeadlineBudgetExhausted and the last attempt's error.
+func (e *DeadlineBudgetError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrDeadlineBudgetExhausted}
+	}
+
+	return []error{ErrDeadlineBudgetExhausted, e.Err}
+}
+
+// Permanent wraps err so that Retry does not retry it. Returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &PermanentError{Err: err}
+}
+
+// RetryableAfter wraps err with a hint that the operation may be retried
+// after d. Returns nil if err is nil.
+func RetryableAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RetryAfterError{Err: err, Delay: d}
+}
+
+// IsPermanent reports whether err or any error in its chain was marked via Permanent.
+func IsPermanent(err error) bool {
+	var permanentErr *PermanentError
+
+	return errors.As(err, &permanentErr)
+}
+
+// retryAfterFromError returns the hinted delay of the first RetryAfterHint in
+// err's chain, if any.
+func retryAfterFromError(err error) (time.Duration, bool) {
+	var hint RetryAfterHint
+	if !errors.As(err, &hint) {
+		return 0, false
+	}
+
+	d := hint.RetryAfter()
+	if d < 0 {
+		return 0, false
+	}
+
+	return d, true
+}
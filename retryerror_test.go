@@ -0,0 +1,42 @@
+package gofuncy_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermanent(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, gofuncy.Permanent(nil))
+
+	base := errors.New("boom")
+	err := fmt.Errorf("wrapped: %w", gofuncy.Permanent(base))
+
+	require.ErrorIs(t, err, base)
+	assert.True(t, gofuncy.IsPermanent(err))
+	assert.False(t, gofuncy.IsPermanent(base))
+	assert.Equal(t, "wrapped: boom", err.Error())
+}
+
+func TestRetryableAfter(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, gofuncy.RetryableAfter(nil, time.Second))
+
+	base := errors.New("throttled")
+	err := gofuncy.RetryableAfter(base, 2*time.Second)
+
+	require.ErrorIs(t, err, base)
+
+	var hint gofuncy.RetryAfterHint
+	require.ErrorAs(t, err, &hint)
+	assert.Equal(t, 2*time.Second, hint.RetryAfter())
+	assert.Equal(t, "throttled (retry after 2s)", err.Error())
+}
@@ -25,6 +25,12 @@ const (
 	goroutinesRejectedName = "gofuncy.goroutines.circuitbreaker.rejected"
 	goroutinesRejectedDesc = "Total number of requests rejected by a circuit breaker"
 
+	goroutinesCoalescedName = "gofuncy.goroutines.singleflight.coalesced"
+	goroutinesCoalescedDesc = "Total number of invocations coalesced into an in-flight call"
+
+	goroutinesShedName = "gofuncy.goroutines.shed"
+	goroutinesShedDesc = "Total number of invocations shed by a load shedder"
+
 	goroutinesStalledName = "gofuncy.goroutines.stalled"
 	goroutinesStalledDesc = "Total number of goroutines that exceeded their stall threshold"
 
@@ -34,9 +40,60 @@ const (
 	goroutinesDurationName = "gofuncy.goroutines.duration.seconds"
 	goroutinesDurationDesc = "Duration of goroutine execution"
 
+	cacheHitsName = "gofuncy.cache.hits"
+	cacheHitsDesc = "Total number of calls served from the result cache"
+
+	cacheMissesName = "gofuncy.cache.misses"
+	cacheMissesDesc = "Total number of calls not served from the result cache"
+
+	cacheStaleName = "gofuncy.cache.stale"
+	cacheStaleDesc = "Total number of failed calls served a stale cached result"
+
+	limiterLimitName = "gofuncy.limiter.limit"
+	limiterLimitDesc = "Current concurrency limit of a limiter"
+
+	limiterInFlightName = "gofuncy.limiter.inflight"
+	limiterInFlightDesc = "Number of executions currently admitted by a limiter"
+
+	limiterQueueLengthName = "gofuncy.limiter.queue.length"
+	limiterQueueLengthDesc = "Number of callers waiting to be admitted by a limiter"
+
+	limiterWaitDurationName = "gofuncy.limiter.wait.duration.seconds"
+	limiterWaitDurationDesc = "Time spent waiting to be admitted by a limiter"
+
+	actorMailboxSizeName = "gofuncy.actor.mailbox.size"
+	actorMailboxSizeDesc = "Number of messages waiting in an actor mailbox"
+
+	actorMessagesProcessedName = "gofuncy.actor.messages.processed"
+	actorMessagesProcessedDesc = "Total number of messages processed by an actor"
+
+	actorRestartsName = "gofuncy.actor.restarts"
+	actorRestartsDesc = "Total number of actor restarts after a panic"
+
 	groupsDurationName = "gofuncy.groups.duration.seconds"
 	groupsDurationDesc = "Gofuncy group/map duration histogram"
 
+	groupsTasksActiveName = "gofuncy.groups.tasks.active"
+	groupsTasksActiveDesc = "Number of functions currently running in a long-lived group"
+
+	groupsTasksQueuedName = "gofuncy.groups.tasks.queued"
+	groupsTasksQueuedDesc = "Number of functions waiting for a slot in a long-lived group"
+
+	groupsTasksCancelledName = "gofuncy.groups.tasks.cancelled"
+	groupsTasksCancelledDesc = "Total number of group functions cancelled via their task handle"
+
+	groupsLimitName = "gofuncy.groups.limit"
+	groupsLimitDesc = "Current concurrency limit of a group, 0 if unlimited"
+
+	groupsQueueDepthName = "gofuncy.groups.queue.depth"
+	groupsQueueDepthDesc = "Number of functions waiting in the queue of a group"
+
+	groupsQueueRejectedName = "gofuncy.groups.queue.rejected"
+	groupsQueueRejectedDesc = "Total number of functions rejected or dropped because the queue of a group was full"
+
+	groupsGatherCoverageName = "gofuncy.groups.gather.coverage"
+	groupsGatherCoverageDesc = "Fraction of items that completed by the soft deadline of a gather"
+
 	chansCurrentName = "gofuncy.chans.current"
 	chansCurrentDesc = "Gofuncy open chan up/down count"
 
@@ -50,6 +107,8 @@ const (
 	unitSeconds   = "s"
 	unitChan      = "{chan}"
 	unitMessage   = "{message}"
+	unitCall      = "{call}"
+	unitRatio     = "1"
 )
 
 // default histogram bucket boundaries for goroutine/group durations
@@ -57,6 +116,11 @@ var durationBuckets = metric.WithExplicitBucketBoundaries(
 	0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1.0, 5.0, 10.0, 30.0, 60.0, 300.0, 600.0,
 )
 
+// default histogram bucket boundaries for ratios between 0 and 1
+var ratioBuckets = metric.WithExplicitBucketBoundaries(
+	0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 1.0,
+)
+
 // ------------------------------------------------------------------------------------------------
 // ~ GoroutinesStarted
 // ------------------------------------------------------------------------------------------------
@@ -221,6 +285,88 @@ func (g GoroutinesRejected) Add(ctx context.Context, incr int64, routineName str
 	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
 }
 
+// ------------------------------------------------------------------------------------------------
+// ~ GoroutinesCoalesced
+// ------------------------------------------------------------------------------------------------
+
+// GoroutinesCoalesced counts invocations that shared the result of an in-flight call.
+type GoroutinesCoalesced struct {
+	inst metric.Int64Counter
+}
+
+// NewGoroutinesCoalesced creates a new coalesced invocations counter.
+func NewGoroutinesCoalesced(m metric.Meter) (GoroutinesCoalesced, error) {
+	if m == nil {
+		return GoroutinesCoalesced{}, nil
+	}
+
+	c, err := m.Int64Counter(goroutinesCoalescedName,
+		metric.WithDescription(goroutinesCoalescedDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GoroutinesCoalesced{inst: c}, err
+}
+
+func (GoroutinesCoalesced) Name() string                { return goroutinesCoalescedName }
+func (GoroutinesCoalesced) Unit() string                { return unitGoroutine }
+func (GoroutinesCoalesced) Description() string         { return goroutinesCoalescedDesc }
+func (g GoroutinesCoalesced) Inst() metric.Int64Counter { return g.inst }
+
+func (g GoroutinesCoalesced) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GoroutinesShed
+// ------------------------------------------------------------------------------------------------
+
+// GoroutinesShed counts invocations shed by a load shedder.
+type GoroutinesShed struct {
+	inst metric.Int64Counter
+}
+
+// NewGoroutinesShed creates a new load shedder counter.
+func NewGoroutinesShed(m metric.Meter) (GoroutinesShed, error) {
+	if m == nil {
+		return GoroutinesShed{}, nil
+	}
+
+	c, err := m.Int64Counter(goroutinesShedName,
+		metric.WithDescription(goroutinesShedDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GoroutinesShed{inst: c}, err
+}
+
+func (GoroutinesShed) Name() string                { return goroutinesShedName }
+func (GoroutinesShed) Unit() string                { return unitGoroutine }
+func (GoroutinesShed) Description() string         { return goroutinesShedDesc }
+func (g GoroutinesShed) Inst() metric.Int64Counter { return g.inst }
+
+func (g GoroutinesShed) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
 // ------------------------------------------------------------------------------------------------
 // ~ GoroutinesStalled
 // ------------------------------------------------------------------------------------------------
@@ -353,35 +499,283 @@ func (g GoroutinesDuration) Record(ctx context.Context, value float64, routineNa
 }
 
 // ------------------------------------------------------------------------------------------------
-// ~ GroupsDuration
+// ~ CacheHits
 // ------------------------------------------------------------------------------------------------
 
-// GroupsDuration records the duration of group/map operations.
-type GroupsDuration struct {
+// CacheHits counts calls served from the result cache.
+type CacheHits struct {
+	inst metric.Int64Counter
+}
+
+// NewCacheHits creates a new cache hits counter.
+func NewCacheHits(m metric.Meter) (CacheHits, error) {
+	if m == nil {
+		return CacheHits{}, nil
+	}
+
+	c, err := m.Int64Counter(cacheHitsName,
+		metric.WithDescription(cacheHitsDesc),
+		metric.WithUnit(unitCall),
+	)
+
+	return CacheHits{inst: c}, err
+}
+
+func (CacheHits) Name() string                { return cacheHitsName }
+func (CacheHits) Unit() string                { return unitCall }
+func (CacheHits) Description() string         { return cacheHitsDesc }
+func (g CacheHits) Inst() metric.Int64Counter { return g.inst }
+
+func (g CacheHits) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ CacheMisses
+// ------------------------------------------------------------------------------------------------
+
+// CacheMisses counts calls not served from the result cache.
+type CacheMisses struct {
+	inst metric.Int64Counter
+}
+
+// NewCacheMisses creates a new cache misses counter.
+func NewCacheMisses(m metric.Meter) (CacheMisses, error) {
+	if m == nil {
+		return CacheMisses{}, nil
+	}
+
+	c, err := m.Int64Counter(cacheMissesName,
+		metric.WithDescription(cacheMissesDesc),
+		metric.WithUnit(unitCall),
+	)
+
+	return CacheMisses{inst: c}, err
+}
+
+func (CacheMisses) Name() string                { return cacheMissesName }
+func (CacheMisses) Unit() string                { return unitCall }
+func (CacheMisses) Description() string         { return cacheMissesDesc }
+func (g CacheMisses) Inst() metric.Int64Counter { return g.inst }
+
+func (g CacheMisses) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ CacheStale
+// ------------------------------------------------------------------------------------------------
+
+// CacheStale counts failed calls served a stale cached result.
+type CacheStale struct {
+	inst metric.Int64Counter
+}
+
+// NewCacheStale creates a new stale cache results counter.
+func NewCacheStale(m metric.Meter) (CacheStale, error) {
+	if m == nil {
+		return CacheStale{}, nil
+	}
+
+	c, err := m.Int64Counter(cacheStaleName,
+		metric.WithDescription(cacheStaleDesc),
+		metric.WithUnit(unitCall),
+	)
+
+	return CacheStale{inst: c}, err
+}
+
+func (CacheStale) Name() string                { return cacheStaleName }
+func (CacheStale) Unit() string                { return unitCall }
+func (CacheStale) Description() string         { return cacheStaleDesc }
+func (g CacheStale) Inst() metric.Int64Counter { return g.inst }
+
+func (g CacheStale) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ LimiterLimit
+// ------------------------------------------------------------------------------------------------
+
+// LimiterLimit observes the current concurrency limit of a limiter.
+type LimiterLimit struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewLimiterLimit creates a new limiter limit gauge. Values are reported from a callback
+// registered via metric.Meter.RegisterCallback.
+func NewLimiterLimit(m metric.Meter) (LimiterLimit, error) {
+	if m == nil {
+		return LimiterLimit{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(limiterLimitName,
+		metric.WithDescription(limiterLimitDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return LimiterLimit{inst: g}, err
+}
+
+func (LimiterLimit) Name() string                        { return limiterLimitName }
+func (LimiterLimit) Unit() string                        { return unitGoroutine }
+func (LimiterLimit) Description() string                 { return limiterLimitDesc }
+func (g LimiterLimit) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g LimiterLimit) Observe(o metric.Observer, value int64, limiterName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.LimiterName(limiterName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.LimiterName(limiterName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ LimiterInFlight
+// ------------------------------------------------------------------------------------------------
+
+// LimiterInFlight observes the number of executions currently admitted by a limiter.
+type LimiterInFlight struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewLimiterInFlight creates a new limiter in-flight gauge. Values are reported from a callback
+// registered via metric.Meter.RegisterCallback.
+func NewLimiterInFlight(m metric.Meter) (LimiterInFlight, error) {
+	if m == nil {
+		return LimiterInFlight{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(limiterInFlightName,
+		metric.WithDescription(limiterInFlightDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return LimiterInFlight{inst: g}, err
+}
+
+func (LimiterInFlight) Name() string                        { return limiterInFlightName }
+func (LimiterInFlight) Unit() string                        { return unitGoroutine }
+func (LimiterInFlight) Description() string                 { return limiterInFlightDesc }
+func (g LimiterInFlight) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g LimiterInFlight) Observe(o metric.Observer, value int64, limiterName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.LimiterName(limiterName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.LimiterName(limiterName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ LimiterQueueLength
+// ------------------------------------------------------------------------------------------------
+
+// LimiterQueueLength tracks the number of callers waiting to be admitted by a limiter.
+type LimiterQueueLength struct {
+	inst metric.Int64UpDownCounter
+}
+
+// NewLimiterQueueLength creates a new limiter queue length up-down counter.
+func NewLimiterQueueLength(m metric.Meter) (LimiterQueueLength, error) {
+	if m == nil {
+		return LimiterQueueLength{}, nil
+	}
+
+	c, err := m.Int64UpDownCounter(limiterQueueLengthName,
+		metric.WithDescription(limiterQueueLengthDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return LimiterQueueLength{inst: c}, err
+}
+
+func (LimiterQueueLength) Name() string                      { return limiterQueueLengthName }
+func (LimiterQueueLength) Unit() string                      { return unitGoroutine }
+func (LimiterQueueLength) Description() string               { return limiterQueueLengthDesc }
+func (g LimiterQueueLength) Inst() metric.Int64UpDownCounter { return g.inst }
+
+func (g LimiterQueueLength) Add(ctx context.Context, incr int64, limiterName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.LimiterName(limiterName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.LimiterName(limiterName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ LimiterWaitDuration
+// ------------------------------------------------------------------------------------------------
+
+// LimiterWaitDuration records the time spent waiting to be admitted by a limiter.
+type LimiterWaitDuration struct {
 	inst metric.Float64Histogram
 }
 
-// NewGroupsDuration creates a new group duration histogram.
-func NewGroupsDuration(m metric.Meter) (GroupsDuration, error) {
+// NewLimiterWaitDuration creates a new limiter wait duration histogram.
+func NewLimiterWaitDuration(m metric.Meter) (LimiterWaitDuration, error) {
 	if m == nil {
-		return GroupsDuration{}, nil
+		return LimiterWaitDuration{}, nil
 	}
 
-	h, err := m.Float64Histogram(groupsDurationName,
-		metric.WithDescription(groupsDurationDesc),
+	h, err := m.Float64Histogram(limiterWaitDurationName,
+		metric.WithDescription(limiterWaitDurationDesc),
 		metric.WithUnit(unitSeconds),
 		durationBuckets,
 	)
 
-	return GroupsDuration{inst: h}, err
+	return LimiterWaitDuration{inst: h}, err
 }
 
-func (GroupsDuration) Name() string                    { return groupsDurationName }
-func (GroupsDuration) Unit() string                    { return unitSeconds }
-func (GroupsDuration) Description() string             { return groupsDurationDesc }
-func (g GroupsDuration) Inst() metric.Float64Histogram { return g.inst }
+func (LimiterWaitDuration) Name() string                    { return limiterWaitDurationName }
+func (LimiterWaitDuration) Unit() string                    { return unitSeconds }
+func (LimiterWaitDuration) Description() string             { return limiterWaitDurationDesc }
+func (g LimiterWaitDuration) Inst() metric.Float64Histogram { return g.inst }
 
-func (g GroupsDuration) Record(ctx context.Context, value float64, routineName string, hasError bool, attrs ...attribute.KeyValue) {
+func (g LimiterWaitDuration) Record(ctx context.Context, value float64, routineName string, hasError bool, attrs ...attribute.KeyValue) {
 	if g.inst == nil {
 		return
 	}
@@ -401,6 +795,484 @@ func (g GroupsDuration) Record(ctx context.Context, value float64, routineName s
 	)...))
 }
 
+// ------------------------------------------------------------------------------------------------
+// ~ ActorMailboxSize
+// ------------------------------------------------------------------------------------------------
+
+// ActorMailboxSize observes the number of messages waiting in an actor mailbox.
+type ActorMailboxSize struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewActorMailboxSize creates a new actor mailbox size gauge. Values are reported from a
+// callback registered via metric.Meter.RegisterCallback.
+func NewActorMailboxSize(m metric.Meter) (ActorMailboxSize, error) {
+	if m == nil {
+		return ActorMailboxSize{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(actorMailboxSizeName,
+		metric.WithDescription(actorMailboxSizeDesc),
+		metric.WithUnit(unitMessage),
+	)
+
+	return ActorMailboxSize{inst: g}, err
+}
+
+func (ActorMailboxSize) Name() string                        { return actorMailboxSizeName }
+func (ActorMailboxSize) Unit() string                        { return unitMessage }
+func (ActorMailboxSize) Description() string                 { return actorMailboxSizeDesc }
+func (g ActorMailboxSize) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g ActorMailboxSize) Observe(o metric.Observer, value int64, actorName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.ActorName(actorName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.ActorName(actorName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ ActorMessagesProcessed
+// ------------------------------------------------------------------------------------------------
+
+// ActorMessagesProcessed counts messages processed by an actor.
+type ActorMessagesProcessed struct {
+	inst metric.Int64Counter
+}
+
+// NewActorMessagesProcessed creates a new actor messages processed counter.
+func NewActorMessagesProcessed(m metric.Meter) (ActorMessagesProcessed, error) {
+	if m == nil {
+		return ActorMessagesProcessed{}, nil
+	}
+
+	c, err := m.Int64Counter(actorMessagesProcessedName,
+		metric.WithDescription(actorMessagesProcessedDesc),
+		metric.WithUnit(unitMessage),
+	)
+
+	return ActorMessagesProcessed{inst: c}, err
+}
+
+func (ActorMessagesProcessed) Name() string                { return actorMessagesProcessedName }
+func (ActorMessagesProcessed) Unit() string                { return unitMessage }
+func (ActorMessagesProcessed) Description() string         { return actorMessagesProcessedDesc }
+func (g ActorMessagesProcessed) Inst() metric.Int64Counter { return g.inst }
+
+func (g ActorMessagesProcessed) Add(ctx context.Context, incr int64, actorName string, hasError bool, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(
+			semconv.ActorName(actorName),
+			semconv.Error(hasError),
+		))
+
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs,
+		semconv.ActorName(actorName),
+		semconv.Error(hasError),
+	)...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ ActorRestarts
+// ------------------------------------------------------------------------------------------------
+
+// ActorRestarts counts actor restarts after a panic.
+type ActorRestarts struct {
+	inst metric.Int64Counter
+}
+
+// NewActorRestarts creates a new actor restarts counter.
+func NewActorRestarts(m metric.Meter) (ActorRestarts, error) {
+	if m == nil {
+		return ActorRestarts{}, nil
+	}
+
+	c, err := m.Int64Counter(actorRestartsName,
+		metric.WithDescription(actorRestartsDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return ActorRestarts{inst: c}, err
+}
+
+func (ActorRestarts) Name() string                { return actorRestartsName }
+func (ActorRestarts) Unit() string                { return unitGoroutine }
+func (ActorRestarts) Description() string         { return actorRestartsDesc }
+func (g ActorRestarts) Inst() metric.Int64Counter { return g.inst }
+
+func (g ActorRestarts) Add(ctx context.Context, incr int64, actorName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.ActorName(actorName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.ActorName(actorName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsDuration
+// ------------------------------------------------------------------------------------------------
+
+// GroupsDuration records the duration of group/map operations.
+type GroupsDuration struct {
+	inst metric.Float64Histogram
+}
+
+// NewGroupsDuration creates a new group duration histogram.
+func NewGroupsDuration(m metric.Meter) (GroupsDuration, error) {
+	if m == nil {
+		return GroupsDuration{}, nil
+	}
+
+	h, err := m.Float64Histogram(groupsDurationName,
+		metric.WithDescription(groupsDurationDesc),
+		metric.WithUnit(unitSeconds),
+		durationBuckets,
+	)
+
+	return GroupsDuration{inst: h}, err
+}
+
+func (GroupsDuration) Name() string                    { return groupsDurationName }
+func (GroupsDuration) Unit() string                    { return unitSeconds }
+func (GroupsDuration) Description() string             { return groupsDurationDesc }
+func (g GroupsDuration) Inst() metric.Float64Histogram { return g.inst }
+
+func (g GroupsDuration) Record(ctx context.Context, value float64, routineName string, hasError bool, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Record(ctx, value, metric.WithAttributes(
+			semconv.RoutineName(routineName),
+			semconv.Error(hasError),
+		))
+
+		return
+	}
+
+	g.inst.Record(ctx, value, metric.WithAttributes(append(attrs,
+		semconv.RoutineName(routineName),
+		semconv.Error(hasError),
+	)...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsTasksActive
+// ------------------------------------------------------------------------------------------------
+
+// GroupsTasksActive observes the number of functions currently running in a long-lived group.
+type GroupsTasksActive struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewGroupsTasksActive creates a new group active tasks gauge. Values are reported from a
+// callback registered via metric.Meter.RegisterCallback.
+func NewGroupsTasksActive(m metric.Meter) (GroupsTasksActive, error) {
+	if m == nil {
+		return GroupsTasksActive{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(groupsTasksActiveName,
+		metric.WithDescription(groupsTasksActiveDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GroupsTasksActive{inst: g}, err
+}
+
+func (GroupsTasksActive) Name() string                        { return groupsTasksActiveName }
+func (GroupsTasksActive) Unit() string                        { return unitGoroutine }
+func (GroupsTasksActive) Description() string                 { return groupsTasksActiveDesc }
+func (g GroupsTasksActive) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g GroupsTasksActive) Observe(o metric.Observer, value int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsTasksQueued
+// ------------------------------------------------------------------------------------------------
+
+// GroupsTasksQueued observes the number of functions waiting for a slot in a long-lived group.
+type GroupsTasksQueued struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewGroupsTasksQueued creates a new group queued tasks gauge. Values are reported from a
+// callback registered via metric.Meter.RegisterCallback.
+func NewGroupsTasksQueued(m metric.Meter) (GroupsTasksQueued, error) {
+	if m == nil {
+		return GroupsTasksQueued{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(groupsTasksQueuedName,
+		metric.WithDescription(groupsTasksQueuedDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GroupsTasksQueued{inst: g}, err
+}
+
+func (GroupsTasksQueued) Name() string                        { return groupsTasksQueuedName }
+func (GroupsTasksQueued) Unit() string                        { return unitGoroutine }
+func (GroupsTasksQueued) Description() string                 { return groupsTasksQueuedDesc }
+func (g GroupsTasksQueued) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g GroupsTasksQueued) Observe(o metric.Observer, value int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsTasksCancelled
+// ------------------------------------------------------------------------------------------------
+
+// GroupsTasksCancelled counts group functions cancelled via their task handle.
+type GroupsTasksCancelled struct {
+	inst metric.Int64Counter
+}
+
+// NewGroupsTasksCancelled creates a new group task cancellation counter.
+func NewGroupsTasksCancelled(m metric.Meter) (GroupsTasksCancelled, error) {
+	if m == nil {
+		return GroupsTasksCancelled{}, nil
+	}
+
+	c, err := m.Int64Counter(groupsTasksCancelledName,
+		metric.WithDescription(groupsTasksCancelledDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GroupsTasksCancelled{inst: c}, err
+}
+
+func (GroupsTasksCancelled) Name() string                { return groupsTasksCancelledName }
+func (GroupsTasksCancelled) Unit() string                { return unitGoroutine }
+func (GroupsTasksCancelled) Description() string         { return groupsTasksCancelledDesc }
+func (g GroupsTasksCancelled) Inst() metric.Int64Counter { return g.inst }
+
+func (g GroupsTasksCancelled) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsLimit
+// ------------------------------------------------------------------------------------------------
+
+// GroupsLimit observes the current concurrency limit of a group.
+type GroupsLimit struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewGroupsLimit creates a new group limit gauge. Values are reported from a
+// callback registered via metric.Meter.RegisterCallback.
+func NewGroupsLimit(m metric.Meter) (GroupsLimit, error) {
+	if m == nil {
+		return GroupsLimit{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(groupsLimitName,
+		metric.WithDescription(groupsLimitDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GroupsLimit{inst: g}, err
+}
+
+func (GroupsLimit) Name() string                        { return groupsLimitName }
+func (GroupsLimit) Unit() string                        { return unitGoroutine }
+func (GroupsLimit) Description() string                 { return groupsLimitDesc }
+func (g GroupsLimit) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g GroupsLimit) Observe(o metric.Observer, value int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsQueueDepth
+// ------------------------------------------------------------------------------------------------
+
+// GroupsQueueDepth observes the number of functions waiting in the queue of a group.
+type GroupsQueueDepth struct {
+	inst metric.Int64ObservableGauge
+}
+
+// NewGroupsQueueDepth creates a new group queue depth gauge. Values are reported from a
+// callback registered via metric.Meter.RegisterCallback.
+func NewGroupsQueueDepth(m metric.Meter) (GroupsQueueDepth, error) {
+	if m == nil {
+		return GroupsQueueDepth{}, nil
+	}
+
+	g, err := m.Int64ObservableGauge(groupsQueueDepthName,
+		metric.WithDescription(groupsQueueDepthDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GroupsQueueDepth{inst: g}, err
+}
+
+func (GroupsQueueDepth) Name() string                        { return groupsQueueDepthName }
+func (GroupsQueueDepth) Unit() string                        { return unitGoroutine }
+func (GroupsQueueDepth) Description() string                 { return groupsQueueDepthDesc }
+func (g GroupsQueueDepth) Inst() metric.Int64ObservableGauge { return g.inst }
+
+func (g GroupsQueueDepth) Observe(o metric.Observer, value int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		o.ObserveInt64(g.inst, value, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	o.ObserveInt64(g.inst, value, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsQueueRejected
+// ------------------------------------------------------------------------------------------------
+
+// GroupsQueueRejected counts functions rejected or dropped because the queue of a group was full.
+type GroupsQueueRejected struct {
+	inst metric.Int64Counter
+}
+
+// NewGroupsQueueRejected creates a new group queue rejection counter.
+func NewGroupsQueueRejected(m metric.Meter) (GroupsQueueRejected, error) {
+	if m == nil {
+		return GroupsQueueRejected{}, nil
+	}
+
+	c, err := m.Int64Counter(groupsQueueRejectedName,
+		metric.WithDescription(groupsQueueRejectedDesc),
+		metric.WithUnit(unitGoroutine),
+	)
+
+	return GroupsQueueRejected{inst: c}, err
+}
+
+func (GroupsQueueRejected) Name() string                { return groupsQueueRejectedName }
+func (GroupsQueueRejected) Unit() string                { return unitGoroutine }
+func (GroupsQueueRejected) Description() string         { return groupsQueueRejectedDesc }
+func (g GroupsQueueRejected) Inst() metric.Int64Counter { return g.inst }
+
+func (g GroupsQueueRejected) Add(ctx context.Context, incr int64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Add(ctx, incr, metric.WithAttributes(semconv.RoutineName(routineName)))
+		return
+	}
+
+	g.inst.Add(ctx, incr, metric.WithAttributes(append(attrs, semconv.RoutineName(routineName))...))
+}
+
+// ------------------------------------------------------------------------------------------------
+// ~ GroupsGatherCoverage
+// ------------------------------------------------------------------------------------------------
+
+// GroupsGatherCoverage records the fraction of items of a gather that
+// completed by its soft deadline.
+type GroupsGatherCoverage struct {
+	inst metric.Float64Histogram
+}
+
+// NewGroupsGatherCoverage creates a new gather coverage histogram.
+func NewGroupsGatherCoverage(m metric.Meter) (GroupsGatherCoverage, error) {
+	if m == nil {
+		return GroupsGatherCoverage{}, nil
+	}
+
+	h, err := m.Float64Histogram(groupsGatherCoverageName,
+		metric.WithDescription(groupsGatherCoverageDesc),
+		metric.WithUnit(unitRatio),
+		ratioBuckets,
+	)
+
+	return GroupsGatherCoverage{inst: h}, err
+}
+
+func (GroupsGatherCoverage) Name() string                    { return groupsGatherCoverageName }
+func (GroupsGatherCoverage) Unit() string                    { return unitRatio }
+func (GroupsGatherCoverage) Description() string             { return groupsGatherCoverageDesc }
+func (g GroupsGatherCoverage) Inst() metric.Float64Histogram { return g.inst }
+
+func (g GroupsGatherCoverage) Record(ctx context.Context, value float64, routineName string, attrs ...attribute.KeyValue) {
+	if g.inst == nil {
+		return
+	}
+
+	if len(attrs) == 0 {
+		g.inst.Record(ctx, value, metric.WithAttributes(
+			semconv.RoutineName(routineName),
+		))
+
+		return
+	}
+
+	g.inst.Record(ctx, value, metric.WithAttributes(append(attrs,
+		semconv.RoutineName(routineName),
+	)...))
+}
+
 // ------------------------------------------------------------------------------------------------
 // ~ ChansCurrent
 // ------------------------------------------------------------------------------------------------
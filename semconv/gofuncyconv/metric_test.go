@@ -136,6 +136,20 @@ func TestGoroutinesDuration_nilMeter(t *testing.T) {
 	m.Record(context.Background(), 0.5, "test-routine", false)
 }
 
+func TestGoroutinesShed(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGoroutinesShed(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.goroutines.shed", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Total number of invocations shed by a load shedder", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-routine")
+}
+
 func TestGroupsDuration(t *testing.T) {
 	t.Parallel()
 
@@ -229,3 +243,243 @@ func TestMessagesDuration_nilMeter(t *testing.T) {
 
 	m.Record(context.Background(), 0.5, "test-chan")
 }
+
+func TestGoroutinesCoalesced(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGoroutinesCoalesced(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.goroutines.singleflight.coalesced", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Total number of invocations coalesced into an in-flight call", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-routine")
+}
+
+func TestCacheHits(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewCacheHits(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.cache.hits", m.Name())
+	assert.Equal(t, "{call}", m.Unit())
+	assert.Equal(t, "Total number of calls served from the result cache", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-routine")
+}
+
+func TestCacheMisses(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewCacheMisses(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.cache.misses", m.Name())
+	assert.Equal(t, "{call}", m.Unit())
+	assert.Equal(t, "Total number of calls not served from the result cache", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-routine")
+}
+
+func TestCacheStale(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewCacheStale(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.cache.stale", m.Name())
+	assert.Equal(t, "{call}", m.Unit())
+	assert.Equal(t, "Total number of failed calls served a stale cached result", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-routine")
+}
+
+func TestLimiterLimit(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewLimiterLimit(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.limiter.limit", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Current concurrency limit of a limiter", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestLimiterInFlight(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewLimiterInFlight(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.limiter.inflight", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Number of executions currently admitted by a limiter", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestLimiterQueueLength(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewLimiterQueueLength(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.limiter.queue.length", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Number of callers waiting to be admitted by a limiter", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-limiter")
+}
+
+func TestLimiterWaitDuration(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewLimiterWaitDuration(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.limiter.wait.duration.seconds", m.Name())
+	assert.Equal(t, "s", m.Unit())
+	assert.Equal(t, "Time spent waiting to be admitted by a limiter", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Record(context.Background(), 0.5, "test-routine", false)
+	m.Record(context.Background(), 1.0, "test-routine", true)
+}
+
+func TestActorMailboxSize(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewActorMailboxSize(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.actor.mailbox.size", m.Name())
+	assert.Equal(t, "{message}", m.Unit())
+	assert.Equal(t, "Number of messages waiting in an actor mailbox", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestActorMessagesProcessed(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewActorMessagesProcessed(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.actor.messages.processed", m.Name())
+	assert.Equal(t, "{message}", m.Unit())
+	assert.Equal(t, "Total number of messages processed by an actor", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-actor", false)
+	m.Add(context.Background(), 1, "test-actor", true)
+}
+
+func TestActorRestarts(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewActorRestarts(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.actor.restarts", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Total number of actor restarts after a panic", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-actor")
+}
+
+func TestGroupsGatherCoverage(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsGatherCoverage(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.gather.coverage", m.Name())
+	assert.Equal(t, "1", m.Unit())
+	assert.Equal(t, "Fraction of items that completed by the soft deadline of a gather", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Record(context.Background(), 0.5, "test-gather")
+}
+
+func TestGroupsTasksActive(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsTasksActive(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.tasks.active", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Number of functions currently running in a long-lived group", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestGroupsTasksQueued(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsTasksQueued(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.tasks.queued", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Number of functions waiting for a slot in a long-lived group", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestGroupsQueueDepth(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsQueueDepth(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.queue.depth", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Number of functions waiting in the queue of a group", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestGroupsQueueRejected(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsQueueRejected(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.queue.rejected", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Total number of functions rejected or dropped because the queue of a group was full", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-group")
+}
+
+func TestGroupsLimit(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsLimit(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.limit", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Current concurrency limit of a group, 0 if unlimited", m.Description())
+	assert.NotNil(t, m.Inst())
+}
+
+func TestGroupsTasksCancelled(t *testing.T) {
+	t.Parallel()
+
+	m, err := gofuncyconv.NewGroupsTasksCancelled(noop.Meter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gofuncy.groups.tasks.cancelled", m.Name())
+	assert.Equal(t, "{goroutine}", m.Unit())
+	assert.Equal(t, "Total number of group functions cancelled via their task handle", m.Description())
+	assert.NotNil(t, m.Inst())
+
+	m.Add(context.Background(), 1, "test-group")
+}
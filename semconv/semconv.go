@@ -1,6 +1,10 @@
 package semconv
 
-import "go.opentelemetry.io/otel/attribute"
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
 
 // Attribute keys for gofuncy telemetry.
 const (
@@ -18,6 +22,62 @@ const (
 	GroupSizeKey = attribute.Key("gofuncy.group.size")
 	// ErrorKey is the attribute key indicating whether an error occurred.
 	ErrorKey = attribute.Key("error")
+	// RetryAttemptKey is the attribute key for the 1-indexed retry attempt number.
+	RetryAttemptKey = attribute.Key("gofuncy.retry.attempt")
+	// RetryBackoffKey is the attribute key for the backoff delay in seconds
+	// waited before a retry attempt.
+	RetryBackoffKey = attribute.Key("gofuncy.retry.backoff")
+	// CircuitStateKey is the attribute key for the current circuit breaker state.
+	CircuitStateKey = attribute.Key("gofuncy.circuitbreaker.state")
+	// CircuitStateFromKey is the attribute key for the previous circuit breaker state.
+	CircuitStateFromKey = attribute.Key("gofuncy.circuitbreaker.state.from")
+	// LimiterNameKey is the attribute key for the limiter name.
+	LimiterNameKey = attribute.Key("gofuncy.limiter.name")
+	// LimiterTenantKey is the attribute key for the tenant key of a fair limiter.
+	LimiterTenantKey = attribute.Key("gofuncy.limiter.tenant")
+	// LimiterPriorityKey is the attribute key for the priority class of a fair limiter.
+	LimiterPriorityKey = attribute.Key("gofuncy.limiter.priority")
+	// LimiterWaitKey is the attribute key for the time in seconds spent
+	// waiting to be admitted by a limiter.
+	LimiterWaitKey = attribute.Key("gofuncy.limiter.wait.duration")
+	// ExecutorKeyKey is the attribute key for the key of a KeyedExecutor function.
+	ExecutorKeyKey = attribute.Key("gofuncy.executor.key")
+	// ActorNameKey is the attribute key for the actor name.
+	ActorNameKey = attribute.Key("gofuncy.actor.name")
+	// AlternativeKey is the attribute key for the index of the alternative
+	// that decided the outcome of Any.
+	AlternativeKey = attribute.Key("gofuncy.alternative")
+	// AlternativesKey is the attribute key for the indices of the alternatives
+	// that reached the quorum of Quorum.
+	AlternativesKey = attribute.Key("gofuncy.alternatives")
+	// QuorumKey is the attribute key for the number of successes required by Quorum.
+	QuorumKey = attribute.Key("gofuncy.quorum")
+	// GatherCoverageKey is the attribute key for the fraction of items of a
+	// gather that completed by its soft deadline.
+	GatherCoverageKey = attribute.Key("gofuncy.gather.coverage")
+	// GatherTimedOutKey is the attribute key for the number of items of a
+	// gather that had not completed by its soft deadline.
+	GatherTimedOutKey = attribute.Key("gofuncy.gather.timed_out")
+	// CancelCauseKey is the attribute key for the cause a context was cancelled with.
+	CancelCauseKey = attribute.Key("gofuncy.cancel.cause")
+	// TimeoutKey is the attribute key for a configured timeout in seconds.
+	TimeoutKey = attribute.Key("gofuncy.timeout")
+)
+
+// Span event names for gofuncy resilience middlewares.
+const (
+	// EventCircuitStateChange is recorded when a circuit breaker changes state.
+	EventCircuitStateChange = "gofuncy.circuitbreaker.state_change"
+	// EventCircuitRejected is recorded when a circuit breaker rejects a call.
+	EventCircuitRejected = "gofuncy.circuitbreaker.rejected"
+	// EventTimeout is recorded when an invocation exceeds its timeout.
+	EventTimeout = "gofuncy.timeout"
+	// EventFallback is recorded when a fallback function is invoked.
+	EventFallback = "gofuncy.fallback"
+	// EventLimiterWait is recorded when an invocation waited to be admitted by a limiter.
+	EventLimiterWait = "gofuncy.limiter.wait"
+	// EventLimiterTimeout is recorded when an invocation gave up waiting for a limiter.
+	EventLimiterTimeout = "gofuncy.limiter.timeout"
 )
 
 // RoutineName returns an attribute with the goroutine name.
@@ -54,3 +114,88 @@ func GroupSize(v int) attribute.KeyValue {
 func Error(v bool) attribute.KeyValue {
 	return ErrorKey.Bool(v)
 }
+
+// RetryAttempt returns an attribute with the 1-indexed retry attempt number.
+func RetryAttempt(v int) attribute.KeyValue {
+	return RetryAttemptKey.Int(v)
+}
+
+// RetryBackoff returns an attribute with the backoff delay waited before a retry attempt.
+func RetryBackoff(v time.Duration) attribute.KeyValue {
+	return RetryBackoffKey.Float64(v.Seconds())
+}
+
+// CircuitState returns an attribute with the current circuit breaker state.
+func CircuitState(v string) attribute.KeyValue {
+	return CircuitStateKey.String(v)
+}
+
+// CircuitStateFrom returns an attribute with the previous circuit breaker state.
+func CircuitStateFrom(v string) attribute.KeyValue {
+	return CircuitStateFromKey.String(v)
+}
+
+// Timeout returns an attribute with a configured timeout.
+func Timeout(v time.Duration) attribute.KeyValue {
+	return TimeoutKey.Float64(v.Seconds())
+}
+
+// LimiterName returns an attribute with the limiter name.
+func LimiterName(v string) attribute.KeyValue {
+	return LimiterNameKey.String(v)
+}
+
+// LimiterWait returns an attribute with the time spent waiting to be admitted by a limiter.
+func LimiterWait(v time.Duration) attribute.KeyValue {
+	return LimiterWaitKey.Float64(v.Seconds())
+}
+
+// LimiterTenant returns an attribute with the tenant key of a fair limiter.
+func LimiterTenant(v string) attribute.KeyValue {
+	return LimiterTenantKey.String(v)
+}
+
+// LimiterPriority returns an attribute with the priority class of a fair limiter.
+func LimiterPriority(v int) attribute.KeyValue {
+	return LimiterPriorityKey.Int(v)
+}
+
+// ExecutorKey returns an attribute with the key of a KeyedExecutor function.
+func ExecutorKey(v string) attribute.KeyValue {
+	return ExecutorKeyKey.String(v)
+}
+
+// ActorName returns an attribute with the actor name.
+func ActorName(v string) attribute.KeyValue {
+	return ActorNameKey.String(v)
+}
+
+// Alternative returns an attribute with the index of the winning alternative.
+func Alternative(v int) attribute.KeyValue {
+	return AlternativeKey.Int(v)
+}
+
+// Alternatives returns an attribute with the indices of the winning alternatives.
+func Alternatives(v []int) attribute.KeyValue {
+	return AlternativesKey.IntSlice(v)
+}
+
+// Quorum returns an attribute with the number of successes required by Quorum.
+func Quorum(v int) attribute.KeyValue {
+	return QuorumKey.Int(v)
+}
+
+// GatherCoverage returns an attribute with the coverage of a gather.
+func GatherCoverage(v float64) attribute.KeyValue {
+	return GatherCoverageKey.Float64(v)
+}
+
+// GatherTimedOut returns an attribute with the number of timed out items of a gather.
+func GatherTimedOut(v int) attribute.KeyValue {
+	return GatherTimedOutKey.Int(v)
+}
+
+// CancelCause returns an attribute with the cause a context was cancelled with.
+func CancelCause(v string) attribute.KeyValue {
+	return CancelCauseKey.String(v)
+}
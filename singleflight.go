@@ -0,0 +1,87 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/foomo/gofuncy/semconv/gofuncyconv"
+)
+
+// errFlightAborted is the error of waiters whose shared execution panicked.
+var errFlightAborted = errors.New("gofuncy: shared execution aborted")
+
+// flights holds the in-flight shared executions. Keys are scoped by the
+// operation scope and result type, so only invocations sharing WithName (or
+// the call site, without it), the result type and the key returned by the
+// key function are collapsed.
+var flights sync.Map // map[string]*flight
+
+// flight is a shared execution. Its value and error are set before done is
+// closed.
+type flight struct {
+	done   chan struct{}
+	result resultSlot
+	err    error
+}
+
+func withSingleflight(fn Func, keyFn func(ctx context.Context) string, slot *resultSlot, m metric.Meter, name, scope string) Func {
+	coalesced, err := gofuncyconv.NewGoroutinesCoalesced(m)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(ctx context.Context) error {
+		var typ string
+		if slot != nil {
+			typ = slot.typ
+		}
+
+		key := scope + "\x00" + typ + "\x00" + keyFn(ctx)
+		f := &flight{done: make(chan struct{}), err: errFlightAborted}
+
+		if v, loaded := flights.LoadOrStore(key, f); loaded {
+			f = v.(*flight) //nolint:forcetypeassert
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-f.done:
+			}
+
+			coalesced.Add(ctx, 1, name)
+
+			if own := slot.target(ctx); own != nil {
+				own.value = f.result.value
+			}
+
+			return f.err
+		}
+
+		// the first caller runs the execution itself and waits for it even if
+		// its context is done, so its WithLimiter slot is held until it
+		// returned; it writes into the flight's slot, which waiters copy from
+		func() {
+			defer func() {
+				flights.CompareAndDelete(key, f)
+				close(f.done)
+			}()
+
+			sctx := ctx
+			if slot != nil {
+				sctx = redirectResult(ctx, slot, &f.result)
+			}
+
+			f.err = fn(sctx)
+		}()
+
+		if own := slot.target(ctx); own != nil {
+			own.value = f.result.value
+		}
+
+		return f.err
+	}
+}
@@ -0,0 +1,310 @@
+package gofuncy_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/foomo/gofuncy"
+	"github.com/foomo/opentelemetry-go/exporters/glossy/glossymetric"
+	oteltesting "github.com/foomo/opentelemetry-go/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestSingleflight_coalescesConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	mp := oteltesting.ReportMetrics(t, glossymetric.NewTest(t))
+
+	var (
+		calls   atomic.Int32
+		wg      sync.WaitGroup
+		release = make(chan struct{})
+		results = make([]string, 10)
+	)
+
+	keyFn := func(ctx context.Context) string { return "user:1" }
+
+	for i := range results {
+		wg.Go(func() {
+			v, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+				calls.Add(1)
+				<-release
+
+				return "alice", nil
+			},
+				gofuncy.WithName("TestSingleflight_coalescesConcurrentCalls"),
+				gofuncy.WithSingleflight(keyFn),
+				gofuncy.WithMeterProvider(mp),
+			)
+			assert.NoError(t, err)
+
+			results[i] = v
+		})
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	for _, v := range results {
+		assert.Equal(t, "alice", v)
+	}
+}
+
+func TestSingleflight_sharesError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls   atomic.Int32
+		wg      sync.WaitGroup
+		release = make(chan struct{})
+		errs    = make([]error, 5)
+	)
+
+	errBackend := errors.New("backend down")
+
+	for i := range errs {
+		wg.Go(func() {
+			errs[i] = gofuncy.Do(t.Context(), func(ctx context.Context) error {
+				calls.Add(1)
+				<-release
+
+				return errBackend
+			},
+				gofuncy.WithName("TestSingleflight_sharesError"),
+				gofuncy.WithSingleflight(func(ctx context.Context) string { return "k" }),
+			)
+		})
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	for _, err := range errs {
+		require.ErrorIs(t, err, errBackend)
+	}
+}
+
+func TestSingleflight_distinctKeysRunSeparately(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithName("TestSingleflight_distinctKeysRunSeparately"))
+
+	for _, key := range []string{"a", "b", "c"} {
+		g.Add(func(ctx context.Context) error {
+			calls.Add(1)
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		}, gofuncy.WithSingleflight(func(ctx context.Context) string { return key }))
+	}
+
+	require.NoError(t, g.Wait())
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestSingleflight_waiterContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	started := make(chan struct{})
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("TestSingleflight_waiterContextCancelled"),
+		gofuncy.WithSingleflight(func(ctx context.Context) string { return "k" }),
+	}
+
+	go func() {
+		_ = gofuncy.Do(t.Context(), func(ctx context.Context) error {
+			close(started)
+			<-release
+
+			return nil
+		}, opts...)
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := gofuncy.Do(ctx, func(ctx context.Context) error {
+		t.Fatal("should not be called")
+		return nil
+	}, opts...)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSingleflight_leaderContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	written := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	go func() {
+		<-release
+		cancel()
+	}()
+
+	sem := semaphore.NewWeighted(1)
+
+	v, err := gofuncy.DoValue(ctx, func(ctx context.Context) (string, error) {
+		close(release)
+		time.Sleep(10 * time.Millisecond)
+
+		defer close(written)
+
+		// the caller's context is done, but its limiter slot is still held
+		assert.False(t, sem.TryAcquire(1))
+
+		// ignores ctx and writes its value after the caller's context is done
+		return "late", nil
+	},
+		gofuncy.WithName("TestSingleflight_leaderContextCancelled"),
+		gofuncy.WithSingleflight(func(ctx context.Context) string { return "k" }),
+		gofuncy.WithLimiter(sem),
+	)
+
+	// the first caller waits for the shared execution
+	require.NoError(t, err)
+	assert.Equal(t, "late", v)
+
+	<-written
+}
+
+func TestSingleflight_scopedByResultType(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	opts := []gofuncy.GoOption{
+		gofuncy.WithName("TestSingleflight_scopedByResultType"),
+		gofuncy.WithSingleflight(func(ctx context.Context) string { return "k" }),
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- gofuncy.Do(t.Context(), func(ctx context.Context) error {
+			close(started)
+			<-release
+
+			return nil
+		}, opts...)
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	v, err := gofuncy.DoValue(ctx, func(ctx context.Context) (string, error) {
+		return "own", nil
+	}, opts...)
+	require.NoError(t, err)
+	assert.Equal(t, "own", v, "must not join a call of another result type")
+
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestSingleflight_scopedByCallSite(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	keyFn := gofuncy.WithSingleflight(func(ctx context.Context) string { return "id:1" })
+
+	done := make(chan string, 1)
+
+	go func() {
+		v, _ := gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+			close(started)
+			<-release
+
+			return "user-name", nil
+		}, keyFn)
+		done <- v
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	// same default name, key and result type, but another call site
+	v, err := gofuncy.DoValue(ctx, func(ctx context.Context) (string, error) {
+		return "order-status", nil
+	}, keyFn)
+	require.NoError(t, err)
+	assert.Equal(t, "order-status", v, "must not join a call from another call site")
+
+	close(release)
+	assert.Equal(t, "user-name", <-done)
+}
+
+func TestSingleflight_coalescesUnnamedCallSite(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls   atomic.Int32
+		wg      sync.WaitGroup
+		release = make(chan struct{})
+	)
+
+	get := func() (string, error) {
+		return gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+			calls.Add(1)
+			<-release
+
+			return "alice", nil
+		}, gofuncy.WithSingleflight(func(ctx context.Context) string { return "user:1" }))
+	}
+
+	for range 5 {
+		wg.Go(func() {
+			v, err := get()
+			assert.NoError(t, err)
+			assert.Equal(t, "alice", v)
+		})
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDoValue_resultTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	cache := gofuncy.NewCache()
+	key := func(ctx context.Context) string { return "k" }
+
+	_, err := gofuncy.DoValue(t.Context(), func(ctx context.Context) (int, error) {
+		return 1, nil
+	}, gofuncy.WithCache(cache, key))
+	require.NoError(t, err)
+
+	_, err = gofuncy.DoValue(t.Context(), func(ctx context.Context) (string, error) {
+		return "", nil
+	}, gofuncy.WithCache(cache, key))
+	require.ErrorIs(t, err, gofuncy.ErrResultType)
+}
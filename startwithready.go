@@ -39,18 +39,16 @@ func StartWithReady(ctx context.Context, fn func(ctx context.Context, ready Read
 	run := withContextInjection(inner, o.name)
 	run = buildChain(run, &o, "gofuncy.startwithready", o.callerSkip+3)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			handleError(ctx, err, o.errorHandler, o.l, o.name)
-			return
-		}
+	if err := admit(ctx, &o); err != nil {
+		handleError(ctx, err, o.errorHandler, o.l, o.name)
+		return
 	}
 
 	go func(ctx context.Context) {
 		defer close(done)
 
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		ctx, cancel := context.WithCancel(ctx)
@@ -18,27 +18,25 @@ func StartWithStop(ctx context.Context, fn func(ctx context.Context, stop StopFu
 		o.detachedTrace = true
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 
 	inner := Func(func(ctx context.Context) error {
-		return fn(ctx, StopFunc(cancel))
+		return fn(ctx, stopFunc(cancel))
 	})
 
 	run := withContextInjection(inner, o.name)
 	run = buildChain(run, &o, "gofuncy.startwithstop", o.callerSkip+3)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			handleError(ctx, err, o.errorHandler, o.l, o.name)
-			cancel()
+	if err := admit(ctx, &o); err != nil {
+		handleError(ctx, err, o.errorHandler, o.l, o.name)
+		cancel(err)
 
-			return
-		}
+		return
 	}
 
 	go func(ctx context.Context) {
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		if ctx.Err() != nil {
@@ -0,0 +1,58 @@
+package gofuncy
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTaskCancelled is the cancellation cause of a Group function cancelled
+// via its Task handle. The error of such a function matches it with
+// errors.Is, which tells it apart from functions that failed.
+var ErrTaskCancelled = errors.New("task cancelled")
+
+// Task is a handle to a function added to a Group with Group.Go.
+type Task struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+	err    error
+}
+
+// Cancel cancels the context of the function with ErrTaskCancelled as the
+// cause, or drops it if it has not started yet. The rest of the group
+// continues. It has no effect once the function completed.
+func (t *Task) Cancel() {
+	t.cancel(ErrTaskCancelled)
+}
+
+// Done returns a channel that is closed when the function completed, or
+// will not run.
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// Err returns the error of the function once Done is closed, and nil before.
+// It matches ErrTaskCancelled if the function was cancelled via Cancel.
+func (t *Task) Err() error {
+	select {
+	case <-t.done:
+		return t.err
+	default:
+		return nil
+	}
+}
+
+// cancelled reports whether the task was cancelled via its handle.
+func (t *groupTask) cancelled() bool {
+	return t.handle != nil && errors.Is(context.Cause(t.ctx), ErrTaskCancelled)
+}
+
+// finish completes the task handle, if any, with err.
+func (t *groupTask) finish(err error) {
+	if t.handle == nil {
+		return
+	}
+
+	t.handle.err = err
+	t.handle.cancel(nil)
+	close(t.handle.done)
+}
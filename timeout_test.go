@@ -88,3 +88,41 @@ func TestTimeout_propagatesNonTimeoutError(t *testing.T) {
 	err := g.Wait()
 	require.EqualError(t, err, "application error")
 }
+
+func TestTotalTimeout_boundsAllAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	start := time.Now()
+
+	err := gofuncy.Do(t.Context(), func(ctx context.Context) error {
+		calls.Add(1)
+		<-ctx.Done()
+
+		return ctx.Err()
+	},
+		gofuncy.WithTimeout(20*time.Millisecond),
+		gofuncy.WithTotalTimeout(50*time.Millisecond),
+		gofuncy.WithRetry(10,
+			gofuncy.RetryBackoff(gofuncy.BackoffConstant(0)),
+			gofuncy.RetryIf(func(err error) bool { return true }),
+		),
+	)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Less(t, calls.Load(), int32(10))
+}
+
+func TestTotalTimeout_mergedInGroupAdd(t *testing.T) {
+	t.Parallel()
+
+	g := gofuncy.NewGroup(t.Context(), gofuncy.WithTotalTimeout(time.Hour))
+	g.Add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, gofuncy.WithTotalTimeout(10*time.Millisecond))
+
+	require.ErrorIs(t, g.Wait(), context.DeadlineExceeded)
+}
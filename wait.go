@@ -23,15 +23,13 @@ func Wait(ctx context.Context, fn Func, opts ...GoOption) func() error {
 		done   = make(chan struct{})
 	)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			close(done)
+	if err := admit(ctx, &o); err != nil {
+		close(done)
 
-			result = err
+		result = err
 
-			return func() error {
-				return result
-			}
+		return func() error {
+			return result
 		}
 	}
 
@@ -39,7 +37,7 @@ func Wait(ctx context.Context, fn Func, opts ...GoOption) func() error {
 		defer close(done)
 
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		result = run(ctx)
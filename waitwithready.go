@@ -33,16 +33,14 @@ func WaitWithReady(ctx context.Context, fn func(ctx context.Context, ready Ready
 
 	var result error
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			close(ready)
-			close(done)
+	if err := admit(ctx, &o); err != nil {
+		close(ready)
+		close(done)
 
-			result = err
+		result = err
 
-			return func() error {
-				return result
-			}
+		return func() error {
+			return result
 		}
 	}
 
@@ -50,7 +48,7 @@ func WaitWithReady(ctx context.Context, fn func(ctx context.Context, ready Ready
 		defer close(done)
 
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		result = run(ctx)
@@ -15,10 +15,10 @@ func WaitWithStop(ctx context.Context, fn func(ctx context.Context, stop StopFun
 		o.name = "gofuncy.waitwithstop"
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 
 	inner := Func(func(ctx context.Context) error {
-		return fn(ctx, StopFunc(cancel))
+		return fn(ctx, stopFunc(cancel))
 	})
 
 	run := withContextInjection(inner, o.name)
@@ -29,16 +29,14 @@ func WaitWithStop(ctx context.Context, fn func(ctx context.Context, stop StopFun
 		done   = make(chan struct{})
 	)
 
-	if o.limiter != nil {
-		if err := o.limiter.Acquire(ctx, 1); err != nil {
-			close(done)
-			cancel()
+	if err := admit(ctx, &o); err != nil {
+		close(done)
+		cancel(err)
 
-			result = err
+		result = err
 
-			return func() error {
-				return result
-			}
+		return func() error {
+			return result
 		}
 	}
 
@@ -46,7 +44,7 @@ func WaitWithStop(ctx context.Context, fn func(ctx context.Context, stop StopFun
 		defer close(done)
 
 		if o.limiter != nil {
-			defer o.limiter.Release(1)
+			defer o.limiter.Release(o.limiterWeight())
 		}
 
 		result = run(ctx)